@@ -7,6 +7,7 @@ package float64op
 
 // MonoIDFloat64 is a set of float64's that closed under an associative binary operation
 type MonoIDFloat64 interface {
+	BinaryOpFloat64
 	Zero() float64
 	Reduce(done <-chan struct{}, slice <-chan float64) <-chan float64
 }
@@ -0,0 +1,17 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package graphblas
+
+// Descriptor modifies the behaviour of an operation, a nil or zero Descriptor is the default behaviour
+type Descriptor struct {
+	// TransposeFirst the first input is transposed before the operation
+	TransposeFirst bool
+}
+
+// Transposed the first input is transposed
+func (s *Descriptor) Transposed() bool {
+	return s != nil && s.TransposeFirst
+}
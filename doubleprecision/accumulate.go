@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+// accumulate writes the result t into the matrix through the mask
+//  C<M> ⊙= T
+// with an accumulator elements in both are combined and elements only in the matrix are kept,
+// without one the unmasked elements of the matrix are replaced by t including removing those not in t
+func accumulate(ctx context.Context, t Matrix, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	if t.Rows() != matrix.Rows() || t.Columns() != matrix.Columns() {
+		log.Panicf("Can not accumulate found size mismatch %+v×%+v, %+v×%+v", t.Rows(), t.Columns(), matrix.Rows(), matrix.Columns())
+	}
+
+	if mask == nil {
+		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
+	}
+
+	if mask.Rows() != matrix.Rows() {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
+	}
+
+	if mask.Columns() != matrix.Columns() {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
+	}
+
+	if accumulator == nil {
+		removed := [][2]int{}
+		for iterator := matrix.Enumerate(); iterator.HasNext(); {
+			r, c, _ := iterator.Next()
+			if !mask.Element(r, c) && !t.Has(r, c) {
+				removed = append(removed, [2]int{r, c})
+			}
+		}
+
+		for _, element := range removed {
+			matrix.Remove(element[0], element[1])
+		}
+	}
+
+	for iterator := t.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, value := iterator.Next()
+			if mask.Element(r, c) {
+				continue
+			}
+
+			if accumulator != nil && matrix.Has(r, c) {
+				matrix.Update(r, c, func(v float64) float64 {
+					return accumulator.Apply(v, value)
+				})
+			} else {
+				matrix.Set(r, c, value)
+			}
+		}
+	}
+}
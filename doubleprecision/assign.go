@@ -0,0 +1,182 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+// Assign a matrix to the rows and columns of a matrix, the mask is the size of the output matrix
+//  C<M>(rows, cols) ⊙= A
+func Assign(ctx context.Context, s Matrix, rows, cols Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	mask = assignMask(mask, matrix.Rows(), matrix.Columns())
+	assign(ctx, s, rows.indices(matrix.Rows()), cols.indices(matrix.Columns()), func(r, c, i, j int) bool {
+		return mask.Element(r, c)
+	}, accumulator, matrix)
+}
+
+// AssignConstant a value to every element at the rows and columns of a matrix, the mask is the size of the output matrix
+//  C<M>(rows, cols) ⊙= x
+func AssignConstant(ctx context.Context, value float64, rows, cols Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	mask = assignMask(mask, matrix.Rows(), matrix.Columns())
+	assignConstant(ctx, value, rows.indices(matrix.Rows()), cols.indices(matrix.Columns()), func(r, c, i, j int) bool {
+		return mask.Element(r, c)
+	}, accumulator, matrix)
+}
+
+// AssignVector a vector to the indices of a vector, the mask is the size of the output vector
+//  w<m>(indices) ⊙= u
+func AssignVector(ctx context.Context, s Vector, indices Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	Assign(ctx, s, indices, All, mask, accumulator, vector)
+}
+
+// AssignConstantVector a value to every element at the indices of a vector, the mask is the size of the output vector
+//  w<m>(indices) ⊙= x
+func AssignConstantVector(ctx context.Context, value float64, indices Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	AssignConstant(ctx, value, indices, All, mask, accumulator, vector)
+}
+
+// SubAssign a matrix to the rows and columns of a matrix, the mask is the size of the assigned sub matrix
+//  C(rows, cols)<M> ⊙= A
+func SubAssign(ctx context.Context, s Matrix, rows, cols Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	mask = assignMask(mask, s.Rows(), s.Columns())
+	assign(ctx, s, rows.indices(matrix.Rows()), cols.indices(matrix.Columns()), func(r, c, i, j int) bool {
+		return mask.Element(i, j)
+	}, accumulator, matrix)
+}
+
+// SubAssignConstant a value to every element at the rows and columns of a matrix, the mask is the size of the assigned sub matrix
+//  C(rows, cols)<M> ⊙= x
+func SubAssignConstant(ctx context.Context, value float64, rows, cols Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	rowIndices, colIndices := rows.indices(matrix.Rows()), cols.indices(matrix.Columns())
+	mask = assignMask(mask, len(rowIndices), len(colIndices))
+	assignConstant(ctx, value, rowIndices, colIndices, func(r, c, i, j int) bool {
+		return mask.Element(i, j)
+	}, accumulator, matrix)
+}
+
+// SubAssignVector a vector to the indices of a vector, the mask is the size of the assigned sub vector
+//  w(indices)<m> ⊙= u
+func SubAssignVector(ctx context.Context, s Vector, indices Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	SubAssign(ctx, s, indices, All, mask, accumulator, vector)
+}
+
+// SubAssignConstantVector a value to every element at the indices of a vector, the mask is the size of the assigned sub vector
+//  w(indices)<m> ⊙= x
+func SubAssignConstantVector(ctx context.Context, value float64, indices Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	SubAssignConstant(ctx, value, indices, All, mask, accumulator, vector)
+}
+
+// assignMask the mask of r×c, an empty mask when nil
+func assignMask(mask GraphBLAS.Mask, r, c int) GraphBLAS.Mask {
+	if mask == nil {
+		return GraphBLAS.NewEmptyMask(r, c)
+	}
+
+	if mask.Rows() != r {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), r)
+	}
+
+	if mask.Columns() != c {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), c)
+	}
+
+	return mask
+}
+
+// assignConstant writes the value to every element at the rows and columns of the matrix,
+// every element of the region is assigned so nothing is removed
+func assignConstant(ctx context.Context, value float64, rows, cols []int, masked func(r, c, i, j int) bool, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	for i, r := range rows {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		for j, c := range cols {
+			if masked(r, c, i, j) {
+				continue
+			}
+
+			if accumulator != nil && matrix.Has(r, c) {
+				matrix.Update(r, c, func(v float64) float64 {
+					return accumulator.Apply(v, value)
+				})
+			} else {
+				matrix.Set(r, c, value)
+			}
+		}
+	}
+}
+
+// assign writes the elements of s to the rows and columns of the matrix,
+// masked reports if the element r-th, c-th of the matrix assigned from the i-th, j-th of s is masked
+func assign(ctx context.Context, s Matrix, rows, cols []int, masked func(r, c, i, j int) bool, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	if s.Rows() != len(rows) || s.Columns() != len(cols) {
+		log.Panicf("Can not assign %+v×%+v to %+v×%+v", s.Rows(), s.Columns(), len(rows), len(cols))
+	}
+
+	// without an accumulator the elements of the region not in s are removed
+	if accumulator == nil {
+		rowPosition := make(map[int]int, len(rows))
+		for i, r := range rows {
+			rowPosition[r] = i
+		}
+
+		colPosition := make(map[int]int, len(cols))
+		for j, c := range cols {
+			colPosition[c] = j
+		}
+
+		removed := [][2]int{}
+		for iterator := matrix.Enumerate(); iterator.HasNext(); {
+			r, c, _ := iterator.Next()
+			i, found := rowPosition[r]
+			if !found {
+				continue
+			}
+
+			j, found := colPosition[c]
+			if !found {
+				continue
+			}
+
+			if !masked(r, c, i, j) && !s.Has(i, j) {
+				removed = append(removed, [2]int{r, c})
+			}
+		}
+
+		for _, element := range removed {
+			matrix.Remove(element[0], element[1])
+		}
+	}
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			i, j, value := iterator.Next()
+			r, c := rows[i], cols[j]
+			if masked(r, c, i, j) {
+				continue
+			}
+
+			if accumulator != nil && matrix.Has(r, c) {
+				matrix.Update(r, c, func(v float64) float64 {
+					return accumulator.Apply(v, value)
+				})
+			} else {
+				matrix.Set(r, c, value)
+			}
+		}
+	}
+}
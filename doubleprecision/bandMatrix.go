@@ -0,0 +1,333 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*BandMatrix)(nil)).Elem())
+}
+
+// BandMatrix a matrix with all elements outside a band around the main diagonal zero,
+// the band is stored by diagonals as in LAPACK so the element at r-th, c-th is at
+//  (upper + r - c) * columns + c
+type BandMatrix struct {
+	r      int // number of rows in the matrix
+	c      int // number of columns in the matrix
+	lower  int // number of diagonals below the main diagonal
+	upper  int // number of diagonals above the main diagonal
+	values []float64
+}
+
+// NewBandMatrix returns a BandMatrix
+func NewBandMatrix(r, c, lower, upper int) *BandMatrix {
+	if lower < 0 || upper < 0 {
+		log.Panicf("Can not make a band matrix with bandwidths %+v, %+v", lower, upper)
+	}
+	return &BandMatrix{r: r, c: c, lower: lower, upper: upper, values: make([]float64, (lower+upper+1)*c)}
+}
+
+// NewBandMatrixFromArray returns a BandMatrix, only the band of the data is read
+func NewBandMatrixFromArray(data [][]float64, lower, upper int) *BandMatrix {
+	r := len(data)
+	c := 0
+	if r > 0 {
+		c = len(data[0])
+	}
+
+	s := NewBandMatrix(r, c, lower, upper)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if s.inBand(i, k) {
+				s.values[s.index(i, k)] = data[i][k]
+			}
+		}
+	}
+	return s
+}
+
+// Bandwidth returns the number of diagonals below and above the main diagonal
+func (s *BandMatrix) Bandwidth() (lower, upper int) {
+	return s.lower, s.upper
+}
+
+func (s *BandMatrix) inBand(r, c int) bool {
+	return c-r <= s.upper && r-c <= s.lower
+}
+
+func (s *BandMatrix) index(r, c int) int {
+	return (s.upper+r-c)*s.c + c
+}
+
+func (s *BandMatrix) checkBounds(r, c int) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+}
+
+// rowBand the first and last plus one column of the band on the r-th row
+func (s *BandMatrix) rowBand(r int) (int, int) {
+	start := r - s.lower
+	if start < 0 {
+		start = 0
+	}
+
+	end := r + s.upper + 1
+	if end > s.c {
+		end = s.c
+	}
+
+	return start, end
+}
+
+// Columns the number of columns of the matrix
+func (s *BandMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *BandMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *BandMatrix) Update(r, c int, f func(float64) float64) {
+	s.checkBounds(r, c)
+	if !s.inBand(r, c) {
+		log.Panicf("Element '%+v, %+v' is outside the band", r, c)
+	}
+	i := s.index(r, c)
+	s.values[i] = f(s.values[i])
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *BandMatrix) At(r, c int) float64 {
+	s.checkBounds(r, c)
+	if !s.inBand(r, c) {
+		return 0
+	}
+	return s.values[s.index(r, c)]
+}
+
+// Has the element at r-th, c-th is stored, the whole band is stored
+func (s *BandMatrix) Has(r, c int) bool {
+	return r >= 0 && r < s.r && c >= 0 && c < s.c && s.inBand(r, c)
+}
+
+// Remove sets the element in the band at r-th, c-th to zero
+func (s *BandMatrix) Remove(r, c int) {
+	s.checkBounds(r, c)
+	if s.inBand(r, c) {
+		s.values[s.index(r, c)] = 0
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *BandMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *BandMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+	for r := 0; r < s.r; r++ {
+		if s.inBand(r, c) {
+			columns.SetVec(r, s.values[s.index(r, c)])
+		}
+	}
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *BandMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+	start, end := s.rowBand(r)
+	for c := start; c < end; c++ {
+		rows.SetVec(c, s.values[s.index(r, c)])
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *BandMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+	start, end := s.rowBand(r)
+	for c := start; c < end; c++ {
+		rows[c] = s.values[s.index(r, c)]
+	}
+	return rows
+}
+
+// Copy copies the matrix
+func (s *BandMatrix) Copy() Matrix {
+	values := make([]float64, len(s.values))
+	copy(values, s.values)
+	return &BandMatrix{r: s.r, c: s.c, lower: s.lower, upper: s.upper, values: values}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *BandMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *BandMatrix) Multiply(m Matrix) Matrix {
+	matrix := NewCSRMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix, the result is a CSRMatrix
+func (s *BandMatrix) Add(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix, the result is a CSRMatrix
+func (s *BandMatrix) Subtract(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix, the result is a CSRMatrix
+func (s *BandMatrix) Negative() Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns, the bandwidths below and above the diagonal are swapped
+func (s *BandMatrix) Transpose() Matrix {
+	matrix := NewBandMatrix(s.c, s.r, s.upper, s.lower)
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		matrix.values[matrix.index(c, r)] = value
+	}
+	return matrix
+}
+
+// Equal the two matrices are equal
+func (s *BandMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *BandMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *BandMatrix) Size() int {
+	return s.r * s.c
+}
+
+// Values the number of elements in the band
+func (s *BandMatrix) Values() int {
+	return s.NVals()
+}
+
+// NVals the number of elements in the band
+func (s *BandMatrix) NVals() int {
+	count := 0
+	for r := 0; r < s.r; r++ {
+		start, end := s.rowBand(r)
+		if end > start {
+			count += end - start
+		}
+	}
+	return count
+}
+
+// Clear sets all elements in the band to zero
+func (s *BandMatrix) Clear() {
+	for i := range s.values {
+		s.values[i] = 0
+	}
+}
+
+// Enumerate iterates through all elements in the band by row
+func (s *BandMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+// Map replace each element in the band with the result of applying a function to its value
+func (s *BandMatrix) Map() Map {
+	return s.iterator()
+}
+
+type bandMatrixIterator struct {
+	matrix *BandMatrix
+	r      int
+	c      int
+	end    int
+}
+
+func (s *BandMatrix) iterator() *bandMatrixIterator {
+	i := &bandMatrixIterator{matrix: s, r: -1}
+	i.advance()
+	return i
+}
+
+// advance moves to the next row with a non empty band when the current row is done
+func (s *bandMatrixIterator) advance() {
+	for s.c >= s.end && s.r < s.matrix.r {
+		s.r++
+		if s.r < s.matrix.r {
+			s.c, s.end = s.matrix.rowBand(s.r)
+		}
+	}
+}
+
+// HasNext checks the iterator has any more values
+func (s *bandMatrixIterator) HasNext() bool {
+	return s.r < s.matrix.r
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *bandMatrixIterator) Next() (int, int, float64) {
+	r, c := s.r, s.c
+	s.c++
+	s.advance()
+	return r, c, s.matrix.values[s.matrix.index(r, c)]
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *bandMatrixIterator) Map(f func(int, int, float64) float64) {
+	r, c := s.r, s.c
+	s.c++
+	s.advance()
+	i := s.matrix.index(r, c)
+	s.matrix.values[i] = f(r, c, s.matrix.values[i])
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *BandMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
@@ -1,9 +1,11 @@
 package doubleprecision_test
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 
+	"github.com/rossmerr/graphblas/binaryop/float64op"
 	"github.com/rossmerr/graphblas/doubleprecision"
 )
 
@@ -89,6 +91,91 @@ func BenchmarkMatrixCSCAdd(b *testing.B) {
 	}
 }
 
+func BenchmarkMatrixCSRSet(b *testing.B) {
+	rows, cols, vals := triplets(1000, 10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := doubleprecision.NewCSRMatrix(1000, 1000)
+		for k := range vals {
+			s.Set(rows[k], cols[k], vals[k])
+		}
+	}
+}
+
+func BenchmarkMatrixCOOBuild(b *testing.B) {
+	rows, cols, vals := triplets(1000, 10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := doubleprecision.NewCOOMatrix(1000, 1000)
+		s.Build(rows, cols, vals, float64op.Addition)
+		s.ToCSRMatrix()
+	}
+}
+
+func BenchmarkMatrixCSRMatrixVectorMultiply(b *testing.B) {
+	s, x := mesh(10000)
+	y := doubleprecision.NewDenseVector(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		doubleprecision.MatrixVectorMultiply(context.Background(), s, x, nil, y)
+	}
+}
+
+func BenchmarkMatrixELLMatrixVectorMultiply(b *testing.B) {
+	s, x := mesh(10000)
+	ell := doubleprecision.NewELLMatrixFromMatrix(s)
+	y := doubleprecision.NewDenseVector(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		doubleprecision.MatrixVectorMultiply(context.Background(), ell, x, nil, y)
+	}
+}
+
+func BenchmarkMatrixSELLMatrixVectorMultiply(b *testing.B) {
+	s, x := mesh(10000)
+	sell := doubleprecision.NewSELLMatrixFromMatrix(s, 8, 64)
+	y := doubleprecision.NewDenseVector(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		doubleprecision.MatrixVectorMultiply(context.Background(), sell, x, nil, y)
+	}
+}
+
+// mesh a banded matrix with five elements a row and a dense vector
+func mesh(n int) (doubleprecision.Matrix, doubleprecision.Vector) {
+	coo := doubleprecision.NewCOOMatrix(n, n)
+	rows, cols, vals := []int{}, []int{}, []float64{}
+	for r := 0; r < n; r++ {
+		for _, c := range []int{r - 100, r - 1, r, r + 1, r + 100} {
+			if c >= 0 && c < n {
+				rows = append(rows, r)
+				cols = append(cols, c)
+				vals = append(vals, rand.Float64())
+			}
+		}
+	}
+	coo.Build(rows, cols, vals, nil)
+
+	x := doubleprecision.NewDenseVector(n)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, rand.Float64())
+	}
+
+	return coo.ToCSRMatrix(), x
+}
+
+func triplets(n, nnz int) ([]int, []int, []float64) {
+	rows := make([]int, nnz)
+	cols := make([]int, nnz)
+	vals := make([]float64, nnz)
+	for i := range vals {
+		rows[i] = rand.Intn(n)
+		cols[i] = rand.Intn(n)
+		vals[i] = rand.Float64() + 1
+	}
+	return rows, cols, vals
+}
+
 func dense(n int) doubleprecision.Matrix {
 	aData := make([][]float64, n)
 	for r := range aData {
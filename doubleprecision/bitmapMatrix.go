@@ -0,0 +1,354 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"math/bits"
+	"reflect"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*BitmapMatrix)(nil)).Elem())
+}
+
+// BitmapMatrix dense values with a bitset marking which elements are present,
+// unlike the compressed formats an element can be present with a value of zero
+type BitmapMatrix struct {
+	r       int // number of rows in the matrix
+	c       int // number of columns in the matrix
+	values  []float64
+	present []uint64
+	count   int // number of present elements
+}
+
+// NewBitmapMatrix returns a BitmapMatrix
+func NewBitmapMatrix(r, c int) *BitmapMatrix {
+	return newBitmapMatrix(r, c)
+}
+
+// NewBitmapMatrixFromArray returns a BitmapMatrix with every element present
+func NewBitmapMatrixFromArray(data [][]float64) *BitmapMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := newBitmapMatrix(r, c)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			s.Set(i, k, data[i][k])
+		}
+	}
+	return s
+}
+
+func newBitmapMatrix(r, c int) *BitmapMatrix {
+	s := &BitmapMatrix{
+		r:       r,
+		c:       c,
+		values:  make([]float64, r*c),
+		present: make([]uint64, (r*c+63)/64),
+	}
+	return s
+}
+
+// newBitmapMatrixFromMatrix copies the elements of the matrix
+func newBitmapMatrixFromMatrix(m Matrix) *BitmapMatrix {
+	s := newBitmapMatrix(m.Rows(), m.Columns())
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		s.Set(r, c, value)
+	}
+	return s
+}
+
+// ToCSRMatrix converts the matrix to compressed storage by rows
+func (s *BitmapMatrix) ToCSRMatrix() *CSRMatrix {
+	matrix := newCSRMatrix(s.r, s.c, 0)
+	for r := 0; r < s.r; r++ {
+		for c := 0; c < s.c; c++ {
+			i := r*s.c + c
+			if s.has(i) {
+				matrix.cols = append(matrix.cols, c)
+				matrix.values = append(matrix.values, s.values[i])
+			}
+		}
+		matrix.rowStart[r+1] = len(matrix.values)
+	}
+	return matrix
+}
+
+// ToDenseMatrix converts the matrix to full storage, missing elements become zero
+func (s *BitmapMatrix) ToDenseMatrix() *DenseMatrix {
+	return newMatrix(s.r, s.c, func(row []float64, r int) {
+		copy(row, s.values[r*s.c:(r+1)*s.c])
+	})
+}
+
+// Columns the number of columns of the matrix
+func (s *BitmapMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *BitmapMatrix) Rows() int {
+	return s.r
+}
+
+func (s *BitmapMatrix) offset(r, c int) int {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return r*s.c + c
+}
+
+func (s *BitmapMatrix) has(i int) bool {
+	return s.present[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (s *BitmapMatrix) set(i int, value float64) {
+	if !s.has(i) {
+		s.present[i/64] |= 1 << uint(i%64)
+		s.count++
+	}
+	s.values[i] = value
+}
+
+// Has the element at r-th, c-th is present
+func (s *BitmapMatrix) Has(r, c int) bool {
+	return s.has(s.offset(r, c))
+}
+
+// Remove removes the element at r-th, c-th
+func (s *BitmapMatrix) Remove(r, c int) {
+	i := s.offset(r, c)
+	if s.has(i) {
+		s.present[i/64] &^= 1 << uint(i%64)
+		s.values[i] = 0
+		s.count--
+	}
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *BitmapMatrix) Update(r, c int, f func(float64) float64) {
+	i := s.offset(r, c)
+	s.set(i, f(s.values[i]))
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *BitmapMatrix) At(r, c int) float64 {
+	return s.values[s.offset(r, c)]
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *BitmapMatrix) Set(r, c int, value float64) {
+	s.set(s.offset(r, c), value)
+}
+
+// ColumnsAt return the columns at c-th
+func (s *BitmapMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+
+	for r := 0; r < s.r; r++ {
+		if i := r*s.c + c; s.has(i) {
+			columns.SetVec(r, s.values[i])
+		}
+	}
+
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *BitmapMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	for c := 0; c < s.c; c++ {
+		if i := r*s.c + c; s.has(i) {
+			rows.SetVec(c, s.values[i])
+		}
+	}
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *BitmapMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+	copy(rows, s.values[r*s.c:(r+1)*s.c])
+
+	return rows
+}
+
+// Copy copies the matrix
+func (s *BitmapMatrix) Copy() Matrix {
+	matrix := newBitmapMatrix(s.r, s.c)
+
+	copy(matrix.values, s.values)
+	copy(matrix.present, s.present)
+	matrix.count = s.count
+
+	return matrix
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *BitmapMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *BitmapMatrix) Multiply(m Matrix) Matrix {
+	matrix := newBitmapMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *BitmapMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *BitmapMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *BitmapMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *BitmapMatrix) Transpose() Matrix {
+	matrix := newBitmapMatrix(s.c, s.r)
+	Transpose(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Equal the two matrices are equal
+func (s *BitmapMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *BitmapMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *BitmapMatrix) Size() int {
+	return s.Rows() * s.Columns()
+}
+
+// Values the number of present elements in the matrix
+func (s *BitmapMatrix) Values() int {
+	return s.count
+}
+
+// NVals the number of present elements in the matrix
+func (s *BitmapMatrix) NVals() int {
+	return s.count
+}
+
+// Clear removes all elements from a matrix
+func (s *BitmapMatrix) Clear() {
+	s.values = make([]float64, s.r*s.c)
+	s.present = make([]uint64, (s.r*s.c+63)/64)
+	s.count = 0
+}
+
+// Enumerate iterates through all present elements, order is not guaranteed
+func (s *BitmapMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+type bitmapMatrixIterator struct {
+	matrix *BitmapMatrix
+	last   int
+	index  int // the bit after the current element
+	i      int // the current element
+}
+
+func (s *BitmapMatrix) iterator() *bitmapMatrixIterator {
+	i := &bitmapMatrixIterator{
+		matrix: s,
+	}
+	return i
+}
+
+// next moves to the next set bit
+func (s *bitmapMatrixIterator) next() {
+	word := s.index / 64
+	bitset := s.matrix.present[word] >> uint(s.index%64)
+	for bitset == 0 {
+		word++
+		bitset = s.matrix.present[word]
+		s.index = word * 64
+	}
+
+	s.i = s.index + bits.TrailingZeros64(bitset)
+	s.index = s.i + 1
+	s.last++
+}
+
+// HasNext checks the iterator has any more values
+func (s *bitmapMatrixIterator) HasNext() bool {
+	return s.last < s.matrix.count
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *bitmapMatrixIterator) Next() (int, int, float64) {
+	s.next()
+	return s.i / s.matrix.c, s.i % s.matrix.c, s.matrix.values[s.i]
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *BitmapMatrix) Map() Map {
+	t := s.iterator()
+	i := &bitmapMatrixMap{t}
+	return i
+}
+
+type bitmapMatrixMap struct {
+	*bitmapMatrixIterator
+}
+
+// HasNext checks the iterator has any more values
+func (s *bitmapMatrixMap) HasNext() bool {
+	return s.bitmapMatrixIterator.HasNext()
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *bitmapMatrixMap) Map(f func(int, int, float64) float64) {
+	s.next()
+	s.matrix.values[s.i] = f(s.i/s.matrix.c, s.i%s.matrix.c, s.matrix.values[s.i])
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *BitmapMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
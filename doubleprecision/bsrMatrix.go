@@ -0,0 +1,589 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*BSRMatrix)(nil)).Elem())
+}
+
+// BSRMatrix block compressed storage by rows (BSR), the matrix is held as dense b×b blocks
+// only the blocks with a stored element are kept
+type BSRMatrix struct {
+	r        int       // number of rows in the sparse matrix
+	c        int       // number of columns in the sparse matrix
+	b        int       // size of a block
+	values   []float64 // b×b values of each block by row
+	present  []bool    // the element of the block is stored
+	cols     []int     // the block column of each block
+	rowStart []int     // the start of each block row
+	count    int       // number of stored elements
+}
+
+// NewBSRMatrix returns a BSRMatrix with b×b blocks, the rows and columns must be a multiple of b
+func NewBSRMatrix(r, c, b int) *BSRMatrix {
+	return newBSRMatrix(r, c, b, 0)
+}
+
+// NewBSRMatrixFromArray returns a BSRMatrix with b×b blocks
+func NewBSRMatrixFromArray(data [][]float64, b int) *BSRMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := newBSRMatrix(r, c, b, 0)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+func newBSRMatrix(r, c, b int, l int) *BSRMatrix {
+	if b < 1 || r%b != 0 || c%b != 0 {
+		log.Panicf("Can not block %+v×%+v matrix by %+v", r, c, b)
+	}
+
+	s := &BSRMatrix{
+		r:        r,
+		c:        c,
+		b:        b,
+		values:   make([]float64, l*b*b),
+		present:  make([]bool, l*b*b),
+		cols:     make([]int, l),
+		rowStart: make([]int, r/b+1),
+	}
+	return s
+}
+
+// BlockSize the size of a block
+func (s *BSRMatrix) BlockSize() int {
+	return s.b
+}
+
+// Columns the number of columns of the matrix
+func (s *BSRMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *BSRMatrix) Rows() int {
+	return s.r
+}
+
+// blockIndex the block holding the block column in the block row or where it would be inserted
+func (s *BSRMatrix) blockIndex(br, bc int) (int, bool) {
+	start := s.rowStart[br]
+	end := s.rowStart[br+1]
+
+	pointer := start + sort.SearchInts(s.cols[start:end], bc)
+	return pointer, pointer < end && s.cols[pointer] == bc
+}
+
+// element the block and the offset within it of the element at r-th, c-th
+func (s *BSRMatrix) element(r, c int) (int, int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointer, found := s.blockIndex(r/s.b, c/s.b)
+	return pointer, (r%s.b)*s.b + c%s.b, found
+}
+
+func (s *BSRMatrix) insertBlock(pointer, br, bc int) {
+	size := s.b * s.b
+
+	s.cols = append(s.cols[:pointer], append([]int{bc}, s.cols[pointer:]...)...)
+	s.values = append(s.values[:pointer*size], append(make([]float64, size), s.values[pointer*size:]...)...)
+	s.present = append(s.present[:pointer*size], append(make([]bool, size), s.present[pointer*size:]...)...)
+
+	for i := br + 1; i < len(s.rowStart); i++ {
+		s.rowStart[i]++
+	}
+}
+
+func (s *BSRMatrix) removeBlock(pointer, br int) {
+	size := s.b * s.b
+
+	s.cols = append(s.cols[:pointer], s.cols[pointer+1:]...)
+	s.values = append(s.values[:pointer*size], s.values[(pointer+1)*size:]...)
+	s.present = append(s.present[:pointer*size], s.present[(pointer+1)*size:]...)
+
+	for i := br + 1; i < len(s.rowStart); i++ {
+		s.rowStart[i]--
+	}
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *BSRMatrix) Update(r, c int, f func(float64) float64) {
+	pointer, offset, found := s.element(r, c)
+	if !found {
+		s.insertBlock(pointer, r/s.b, c/s.b)
+	}
+
+	i := pointer*s.b*s.b + offset
+	if !s.present[i] {
+		s.present[i] = true
+		s.count++
+	}
+	s.values[i] = f(s.values[i])
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *BSRMatrix) At(r, c int) float64 {
+	if pointer, offset, found := s.element(r, c); found {
+		return s.values[pointer*s.b*s.b+offset]
+	}
+
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *BSRMatrix) Has(r, c int) bool {
+	pointer, offset, found := s.element(r, c)
+	return found && s.present[pointer*s.b*s.b+offset]
+}
+
+// Remove removes the stored element at r-th, c-th, the block is removed with its last element
+func (s *BSRMatrix) Remove(r, c int) {
+	pointer, offset, found := s.element(r, c)
+	if !found {
+		return
+	}
+
+	size := s.b * s.b
+	i := pointer*size + offset
+	if !s.present[i] {
+		return
+	}
+
+	s.present[i] = false
+	s.values[i] = 0
+	s.count--
+
+	for _, p := range s.present[pointer*size : (pointer+1)*size] {
+		if p {
+			return
+		}
+	}
+	s.removeBlock(pointer, r/s.b)
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *BSRMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *BSRMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+	size := s.b * s.b
+
+	for br := 0; br < s.r/s.b; br++ {
+		if pointer, found := s.blockIndex(br, c/s.b); found {
+			for i := 0; i < s.b; i++ {
+				if k := pointer*size + i*s.b + c%s.b; s.present[k] {
+					columns.SetVec(br*s.b+i, s.values[k])
+				}
+			}
+		}
+	}
+
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *BSRMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	s.row(r, func(c int, value float64) {
+		rows.SetVec(c, value)
+	})
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *BSRMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+
+	s.row(r, func(c int, value float64) {
+		rows[c] = value
+	})
+
+	return rows
+}
+
+// row visits the stored elements of the r-th row
+func (s *BSRMatrix) row(r int, f func(int, float64)) {
+	br := r / s.b
+	size := s.b * s.b
+	for pointer := s.rowStart[br]; pointer < s.rowStart[br+1]; pointer++ {
+		for j := 0; j < s.b; j++ {
+			if k := pointer*size + (r%s.b)*s.b + j; s.present[k] {
+				f(s.cols[pointer]*s.b+j, s.values[k])
+			}
+		}
+	}
+}
+
+// Copy copies the matrix
+func (s *BSRMatrix) Copy() Matrix {
+	matrix := newBSRMatrix(s.r, s.c, s.b, len(s.cols))
+
+	copy(matrix.values, s.values)
+	copy(matrix.present, s.present)
+	copy(matrix.cols, s.cols)
+	copy(matrix.rowStart, s.rowStart)
+	matrix.count = s.count
+
+	return matrix
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *BSRMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix, block by block when both have the same block size
+func (s *BSRMatrix) Multiply(m Matrix) Matrix {
+	if bsr, ok := m.(*BSRMatrix); ok && bsr.b == s.b {
+		return s.multiply(context.Background(), bsr)
+	}
+
+	matrix := NewCSRMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// multiply the block product, each block of the result sums the products of the blocks that meet
+//  C_IJ = Σ A_IK B_KJ
+func (s *BSRMatrix) multiply(ctx context.Context, m *BSRMatrix) *BSRMatrix {
+	if m.r != s.c {
+		log.Panicf("Can not multiply matrices found length mismatch %+v, %+v", m.r, s.c)
+	}
+
+	b := s.b
+	size := b * b
+	matrix := newBSRMatrix(s.r, m.c, b, 0)
+
+	for br := 0; br < s.r/b; br++ {
+		select {
+		case <-ctx.Done():
+			return matrix
+		default:
+		}
+
+		accumulator := map[int]int{}
+		values := []float64{}
+		present := []bool{}
+
+		for p := s.rowStart[br]; p < s.rowStart[br+1]; p++ {
+			bk := s.cols[p]
+			for q := m.rowStart[bk]; q < m.rowStart[bk+1]; q++ {
+				block, found := accumulator[m.cols[q]]
+				if !found {
+					block = len(values) / size
+					accumulator[m.cols[q]] = block
+					values = append(values, make([]float64, size)...)
+					present = append(present, make([]bool, size)...)
+				}
+
+				for i := 0; i < b; i++ {
+					for k := 0; k < b; k++ {
+						a := p*size + i*b + k
+						if !s.present[a] {
+							continue
+						}
+						for j := 0; j < b; j++ {
+							if c := q*size + k*b + j; m.present[c] {
+								values[block*size+i*b+j] += s.values[a] * m.values[c]
+								present[block*size+i*b+j] = true
+							}
+						}
+					}
+				}
+			}
+		}
+
+		blockCols := make([]int, 0, len(accumulator))
+		for bc := range accumulator {
+			blockCols = append(blockCols, bc)
+		}
+		sort.Ints(blockCols)
+
+		for _, bc := range blockCols {
+			block := accumulator[bc]
+			matrix.appendBlock(bc, values[block*size:(block+1)*size], present[block*size:(block+1)*size])
+		}
+		matrix.rowStart[br+1] = len(matrix.cols)
+	}
+
+	return matrix
+}
+
+// appendBlock adds a block to the end of the last block row
+func (s *BSRMatrix) appendBlock(bc int, values []float64, present []bool) {
+	s.cols = append(s.cols, bc)
+	s.values = append(s.values, values...)
+	s.present = append(s.present, present...)
+	for _, p := range present {
+		if p {
+			s.count++
+		}
+	}
+}
+
+// Add addition of a matrix by another matrix, block by block when both have the same block size
+func (s *BSRMatrix) Add(m Matrix) Matrix {
+	if bsr, ok := m.(*BSRMatrix); ok && bsr.b == s.b {
+		return s.add(context.Background(), bsr)
+	}
+
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// add the union of the blocks, elements stored in both are summed
+func (s *BSRMatrix) add(ctx context.Context, m *BSRMatrix) *BSRMatrix {
+	if s.r != m.r || s.c != m.c {
+		log.Panicf("Can not add matrices found size mismatch %+v×%+v, %+v×%+v", s.r, s.c, m.r, m.c)
+	}
+
+	b := s.b
+	size := b * b
+	matrix := newBSRMatrix(s.r, s.c, b, 0)
+
+	for br := 0; br < s.r/b; br++ {
+		select {
+		case <-ctx.Done():
+			return matrix
+		default:
+		}
+
+		p, pEnd := s.rowStart[br], s.rowStart[br+1]
+		q, qEnd := m.rowStart[br], m.rowStart[br+1]
+		for p < pEnd || q < qEnd {
+			switch {
+			case q == qEnd || (p < pEnd && s.cols[p] < m.cols[q]):
+				matrix.appendBlock(s.cols[p], s.values[p*size:(p+1)*size], s.present[p*size:(p+1)*size])
+				p++
+			case p == pEnd || m.cols[q] < s.cols[p]:
+				matrix.appendBlock(m.cols[q], m.values[q*size:(q+1)*size], m.present[q*size:(q+1)*size])
+				q++
+			default:
+				values := make([]float64, size)
+				present := make([]bool, size)
+				for i := range values {
+					values[i] = s.values[p*size+i] + m.values[q*size+i]
+					present[i] = s.present[p*size+i] || m.present[q*size+i]
+				}
+				matrix.appendBlock(s.cols[p], values, present)
+				p++
+				q++
+			}
+		}
+		matrix.rowStart[br+1] = len(matrix.cols)
+	}
+
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *BSRMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *BSRMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns, each block is moved and transposed
+func (s *BSRMatrix) Transpose() Matrix {
+	b := s.b
+	size := b * b
+	blockRows := s.r / b
+	blockCols := s.c / b
+
+	matrix := newBSRMatrix(s.c, s.r, b, len(s.cols))
+	matrix.count = s.count
+
+	for _, bc := range s.cols {
+		matrix.rowStart[bc+1]++
+	}
+	for i := 0; i < blockCols; i++ {
+		matrix.rowStart[i+1] += matrix.rowStart[i]
+	}
+
+	next := make([]int, blockCols)
+	copy(next, matrix.rowStart[:blockCols])
+
+	// walking the block rows in order keeps each new block row sorted
+	for br := 0; br < blockRows; br++ {
+		for p := s.rowStart[br]; p < s.rowStart[br+1]; p++ {
+			q := next[s.cols[p]]
+			next[s.cols[p]]++
+			matrix.cols[q] = br
+
+			for i := 0; i < b; i++ {
+				for j := 0; j < b; j++ {
+					matrix.values[q*size+j*b+i] = s.values[p*size+i*b+j]
+					matrix.present[q*size+j*b+i] = s.present[p*size+i*b+j]
+				}
+			}
+		}
+	}
+
+	return matrix
+}
+
+// Equal the two matrices are equal
+func (s *BSRMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *BSRMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *BSRMatrix) Size() int {
+	return s.Rows() * s.Columns()
+}
+
+// Values the number of stored elements in the matrix
+func (s *BSRMatrix) Values() int {
+	return s.count
+}
+
+// NVals the number of stored elements in the matrix
+func (s *BSRMatrix) NVals() int {
+	return s.count
+}
+
+// Clear removes all elements from a matrix
+func (s *BSRMatrix) Clear() {
+	s.values = make([]float64, 0)
+	s.present = make([]bool, 0)
+	s.cols = make([]int, 0)
+	s.rowStart = make([]int, s.r/s.b+1)
+	s.count = 0
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *BSRMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+type bSRMatrixIterator struct {
+	matrix *BSRMatrix
+	last   int
+	br     int
+	index  int // the element after the current element
+	i      int // the current element
+}
+
+func (s *BSRMatrix) iterator() *bSRMatrixIterator {
+	i := &bSRMatrixIterator{
+		matrix: s,
+	}
+	return i
+}
+
+// next moves to the next stored element, block by block
+func (s *bSRMatrixIterator) next() {
+	for !s.matrix.present[s.index] {
+		s.index++
+	}
+	s.i = s.index
+	s.index++
+	s.last++
+
+	size := s.matrix.b * s.matrix.b
+	for s.matrix.rowStart[s.br+1]*size <= s.i {
+		s.br++
+	}
+}
+
+func (s *bSRMatrixIterator) position() (int, int) {
+	b := s.matrix.b
+	size := b * b
+	block := s.i / size
+	offset := s.i % size
+	return s.br*b + offset/b, s.matrix.cols[block]*b + offset%b
+}
+
+// HasNext checks the iterator has any more values
+func (s *bSRMatrixIterator) HasNext() bool {
+	return s.last < s.matrix.count
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *bSRMatrixIterator) Next() (int, int, float64) {
+	s.next()
+	r, c := s.position()
+	return r, c, s.matrix.values[s.i]
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *BSRMatrix) Map() Map {
+	t := s.iterator()
+	i := &bSRMatrixMap{t}
+	return i
+}
+
+type bSRMatrixMap struct {
+	*bSRMatrixIterator
+}
+
+// HasNext checks the iterator has any more values
+func (s *bSRMatrixMap) HasNext() bool {
+	return s.bSRMatrixIterator.HasNext()
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *bSRMatrixMap) Map(f func(int, int, float64) float64) {
+	s.next()
+	r, c := s.position()
+	s.matrix.values[s.i] = f(r, c, s.matrix.values[s.i])
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *BSRMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package centrality ranks the vertices of a graph held as an adjacency matrix
+package centrality
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Convergence reports how an iterative centrality measure finished
+type Convergence struct {
+	// Iterations the number of iterations performed
+	Iterations int
+
+	// Delta the l1 change between the last two iterations
+	Delta float64
+
+	// Converged is true when Delta fell below the tolerance before running out of iterations
+	Converged bool
+}
+
+// norm the euclidean (l2) norm of the vector
+func norm(ctx context.Context, v doubleprecision.Vector) float64 {
+	squared := v.Copy().(doubleprecision.Vector)
+	for iterator := squared.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, value float64) float64 {
+			return value * value
+		})
+	}
+
+	return math.Sqrt(doubleprecision.ReduceVectorToScalar(ctx, squared, nil))
+}
+
+// normalise scales the vector to unit length, a zero vector is left unchanged
+func normalise(ctx context.Context, v doubleprecision.Vector) {
+	n := norm(ctx, v)
+	if n == 0 {
+		return
+	}
+
+	for iterator := v.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, value float64) float64 {
+			return value / n
+		})
+	}
+}
+
+// delta the l1 distance between two vectors
+func delta(ctx context.Context, s, m doubleprecision.Vector) float64 {
+	difference := s.Subtract(m).(doubleprecision.Vector)
+	for iterator := difference.Map(); iterator.HasNext(); {
+		iterator.Map(func(r, c int, value float64) float64 {
+			return math.Abs(value)
+		})
+	}
+
+	return doubleprecision.ReduceVectorToScalar(ctx, difference, nil)
+}
+
+// uniform returns a unit length vector with every element set to the same value
+func uniform(l int) doubleprecision.Vector {
+	vector := doubleprecision.NewDenseVector(l)
+	for i := 0; i < l; i++ {
+		vector.SetVec(i, 1/math.Sqrt(float64(l)))
+	}
+
+	return vector
+}
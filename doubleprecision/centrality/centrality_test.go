@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/centrality"
+)
+
+const tolerance = 1e-6
+
+func equal(t *testing.T, name string, got doubleprecision.Vector, want []float64) {
+	t.Helper()
+	for i, w := range want {
+		if math.Abs(got.AtVec(i)-w) > 1e-4 {
+			t.Errorf("%+v AtVec(%+v) = %+v, want %+v", name, i, got.AtVec(i), w)
+		}
+	}
+}
+
+func TestEigenvector(t *testing.T) {
+	// path 0 - 1 - 2
+	array := [][]float64{
+		[]float64{0, 1, 0},
+		[]float64{1, 0, 1},
+		[]float64{0, 1, 0},
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, convergence := centrality.Eigenvector(context.Background(), tt.s, tolerance, 100)
+			if !convergence.Converged {
+				t.Errorf("%+v Eigenvector did not converge %+v", tt.name, convergence)
+			}
+			equal(t, tt.name, got, []float64{0.5, math.Sqrt2 / 2, 0.5})
+		})
+	}
+}
+
+func TestKatz(t *testing.T) {
+	// directed cycle 0 -> 1 -> 2 -> 0
+	array := [][]float64{
+		[]float64{0, 1, 0},
+		[]float64{0, 0, 1},
+		[]float64{1, 0, 0},
+	}
+	g := doubleprecision.NewCSRMatrixFromArray(array)
+
+	got, convergence := centrality.Katz(context.Background(), g, 0.1, 1, tolerance, 100)
+	if !convergence.Converged {
+		t.Errorf("Katz did not converge %+v", convergence)
+	}
+	v := 1 / math.Sqrt(3)
+	equal(t, "Katz", got, []float64{v, v, v})
+}
+
+func TestKatz_Attenuation(t *testing.T) {
+	// 0 -> 1 -> 2
+	array := [][]float64{
+		[]float64{0, 1, 0},
+		[]float64{0, 0, 1},
+		[]float64{0, 0, 0},
+	}
+	g := doubleprecision.NewCSRMatrixFromArray(array)
+
+	got, _ := centrality.Katz(context.Background(), g, 0.5, 1, tolerance, 100)
+
+	// unnormalised scores are 1, 1.5, 1.75
+	n := math.Sqrt(1 + 1.5*1.5 + 1.75*1.75)
+	equal(t, "Katz", got, []float64{1 / n, 1.5 / n, 1.75 / n})
+}
+
+func TestHITS(t *testing.T) {
+	// vertex 0 points to 1, 2 and 3
+	array := [][]float64{
+		[]float64{0, 1, 1, 1},
+		[]float64{0, 0, 0, 0},
+		[]float64{0, 0, 0, 0},
+		[]float64{0, 0, 0, 0},
+	}
+
+	g := doubleprecision.NewCSRMatrixFromArray(array)
+	hubs, authorities, convergence := centrality.HITS(context.Background(), g, tolerance, 100)
+	if !convergence.Converged {
+		t.Errorf("HITS did not converge %+v", convergence)
+	}
+
+	v := 1 / math.Sqrt(3)
+	equal(t, "hubs", hubs, []float64{1, 0, 0, 0})
+	equal(t, "authorities", authorities, []float64{0, v, v, v})
+}
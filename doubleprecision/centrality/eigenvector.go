@@ -0,0 +1,53 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+	"log"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Eigenvector centrality using power iteration, a vertex scores highly when it is pointed to by other high scoring vertices
+//  x = (Aᵀ + I)x
+// the identity shift keeps the iteration from oscillating on bipartite graphs without changing the eigenvector
+func Eigenvector(ctx context.Context, a doubleprecision.Matrix, tolerance float64, iterations int) (doubleprecision.Vector, Convergence) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not rank a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	at := a.Transpose()
+	x := uniform(n)
+	convergence := Convergence{}
+
+	for convergence.Iterations < iterations {
+		select {
+		case <-ctx.Done():
+			return x, convergence
+		default:
+		}
+
+		convergence.Iterations++
+
+		next := doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(ctx, at, x, nil, next)
+		for r := 0; r < n; r++ {
+			next.SetVec(r, next.AtVec(r)+x.AtVec(r))
+		}
+		normalise(ctx, next)
+
+		convergence.Delta = delta(ctx, next, x)
+		x = next
+		if convergence.Delta < float64(n)*tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	return x, convergence
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+	"log"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// HITS hyperlink-induced topic search, a good hub points to many good authorities and a good authority is pointed to by many good hubs
+//  a = Aᵀh
+//  h = Aa
+func HITS(ctx context.Context, a doubleprecision.Matrix, tolerance float64, iterations int) (hubs, authorities doubleprecision.Vector, convergence Convergence) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not rank a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	at := a.Transpose()
+	hubs = uniform(n)
+	authorities = doubleprecision.NewDenseVector(n)
+
+	for convergence.Iterations < iterations {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		convergence.Iterations++
+
+		authorities = doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(ctx, at, hubs, nil, authorities)
+		normalise(ctx, authorities)
+
+		next := doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(ctx, a, authorities, nil, next)
+		normalise(ctx, next)
+
+		convergence.Delta = delta(ctx, next, hubs)
+		hubs = next
+		if convergence.Delta < float64(n)*tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	return
+}
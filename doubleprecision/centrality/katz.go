@@ -0,0 +1,55 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package centrality
+
+import (
+	"context"
+	"log"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Katz centrality counts the walks ending at each vertex, walks of length k are attenuated by alpha to the power k
+//  x = αAᵀx + β
+// alpha must be less than the reciprocal of the largest eigenvalue of A for the iteration to converge
+func Katz(ctx context.Context, a doubleprecision.Matrix, alpha, beta, tolerance float64, iterations int) (doubleprecision.Vector, Convergence) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not rank a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	at := a.Transpose()
+	x := doubleprecision.NewDenseVector(n)
+	convergence := Convergence{}
+
+	for convergence.Iterations < iterations {
+		select {
+		case <-ctx.Done():
+			normalise(ctx, x)
+			return x, convergence
+		default:
+		}
+
+		convergence.Iterations++
+
+		next := doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(ctx, at, x, nil, next)
+		// every vertex gets beta even when no walk reaches it
+		for r := 0; r < n; r++ {
+			next.SetVec(r, alpha*next.AtVec(r)+beta)
+		}
+
+		convergence.Delta = delta(ctx, next, x)
+		x = next
+		if convergence.Delta < float64(n)*tolerance {
+			convergence.Converged = true
+			break
+		}
+	}
+
+	normalise(ctx, x)
+	return x, convergence
+}
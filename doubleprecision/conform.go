@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+// HyperSwitch the fraction of non-empty rows (or columns) below which a compressed matrix becomes hypersparse,
+// a hypersparse matrix only goes back once it is above twice the fraction so the format does not flip back and forth
+var HyperSwitch = 0.0625
+
+// BitmapSwitch the density at or above which a sparse matrix becomes a bitmap,
+// a bitmap only goes back once it is below half the density
+var BitmapSwitch = 0.1
+
+// Conform returns the matrix in the storage format best suited to its density,
+// from the most to the least sparse hypersparse (DCSRMatrix, DCSCMatrix), sparse (CSRMatrix, CSCMatrix),
+// bitmap (BitmapMatrix) and full (DenseMatrix).
+// The matrix is returned as is when it is already in that format, a DenseMatrix is always full.
+// Hypersparse is tested first so a matrix too large to hold as a bitmap is never converted to one.
+// Operations keep the format of their receiver or output, call Conform on a result to choose its format.
+func Conform(s Matrix) Matrix {
+	switch m := s.(type) {
+	case *CSRMatrix:
+		if hypersparse(nonEmpty(m.rowStart), m.r, 1) {
+			return m.ToDCSRMatrix()
+		}
+		if bitmap(m, 1) {
+			return conformBitmap(m)
+		}
+	case *DCSRMatrix:
+		if hypersparse(len(m.rows), m.r, 2) {
+			return m
+		}
+		if bitmap(m, 1) {
+			return conformBitmap(m)
+		}
+		return m.ToCSRMatrix()
+	case *CSCMatrix:
+		if hypersparse(nonEmpty(m.colStart), m.c, 1) {
+			return m.ToDCSCMatrix()
+		}
+		if bitmap(m, 1) {
+			return conformBitmap(m)
+		}
+	case *DCSCMatrix:
+		if hypersparse(len(m.cols), m.c, 2) {
+			return m
+		}
+		if bitmap(m, 1) {
+			return conformBitmap(m)
+		}
+		return m.ToCSCMatrix()
+	case *BitmapMatrix:
+		if m.count == m.Size() {
+			return m.ToDenseMatrix()
+		}
+		if !bitmap(m, 0.5) {
+			return Conform(m.ToCSRMatrix())
+		}
+	}
+
+	return s
+}
+
+// bitmap the density is at or above the switch, the element count is found in floating point as rows × columns can overflow an int
+func bitmap(s Matrix, scale float64) bool {
+	return float64(s.Values()) >= BitmapSwitch*scale*float64(s.Rows())*float64(s.Columns())
+}
+
+// conformBitmap a full matrix is held as dense otherwise as a bitmap
+func conformBitmap(s Matrix) Matrix {
+	b := newBitmapMatrixFromMatrix(s)
+	if b.count == b.Size() {
+		return b.ToDenseMatrix()
+	}
+	return b
+}
+
+func hypersparse(nonEmpty, n int, scale float64) bool {
+	return float64(nonEmpty) <= HyperSwitch*scale*float64(n)
+}
+
+// nonEmpty the number of non-empty rows (or columns) of compressed storage
+func nonEmpty(start []int) int {
+	count := 0
+	for i := 1; i < len(start); i++ {
+		if start[i-1] < start[i] {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,520 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*COOMatrix)(nil)).Elem())
+}
+
+type cOOKey struct {
+	r int
+	c int
+}
+
+// COOMatrix coordinate storage (COO) of row, column, value triplets
+type COOMatrix struct {
+	r      int // number of rows in the sparse matrix
+	c      int // number of columns in the sparse matrix
+	values []float64
+	rows   []int
+	cols   []int
+	index  map[cOOKey]int
+	sorted bool // the triplets are in row major order
+}
+
+// NewCOOMatrix returns a COOMatrix
+func NewCOOMatrix(r, c int) *COOMatrix {
+	return newCOOMatrix(r, c, 0)
+}
+
+// NewCOOMatrixFromArray returns a COOMatrix
+func NewCOOMatrixFromArray(data [][]float64) *COOMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := newCOOMatrix(r, c, 0)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+func newCOOMatrix(r, c int, l int) *COOMatrix {
+	s := &COOMatrix{
+		r:      r,
+		c:      c,
+		values: make([]float64, l),
+		rows:   make([]int, l),
+		cols:   make([]int, l),
+		index:  make(map[cOOKey]int, l),
+		sorted: true,
+	}
+	return s
+}
+
+// Build replaces the elements of the matrix with the triplets,
+// duplicates are merged with dup in the order they are given, a nil dup panics on a duplicate
+func (s *COOMatrix) Build(rows, cols []int, vals []float64, dup float64op.BinaryOpFloat64) {
+	if len(rows) != len(cols) || len(rows) != len(vals) {
+		log.Panicf("Can not build found length mismatch %+v, %+v, %+v", len(rows), len(cols), len(vals))
+	}
+
+	for i := range rows {
+		if rows[i] < 0 || rows[i] >= s.r {
+			log.Panicf("Row '%+v' is invalid", rows[i])
+		}
+
+		if cols[i] < 0 || cols[i] >= s.c {
+			log.Panicf("Column '%+v' is invalid", cols[i])
+		}
+	}
+
+	order := make([]int, len(vals))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if rows[a] != rows[b] {
+			return rows[a] < rows[b]
+		}
+		return cols[a] < cols[b]
+	})
+
+	s.values = make([]float64, 0, len(vals))
+	s.rows = make([]int, 0, len(vals))
+	s.cols = make([]int, 0, len(vals))
+
+	for i, p := range order {
+		last := len(s.values) - 1
+		if i > 0 && rows[p] == rows[order[i-1]] && cols[p] == cols[order[i-1]] {
+			if dup == nil {
+				log.Panicf("Can not build found duplicate %+v, %+v", rows[p], cols[p])
+			}
+			s.values[last] = dup.Apply(s.values[last], vals[p])
+			continue
+		}
+
+		s.values = append(s.values, vals[p])
+		s.rows = append(s.rows, rows[p])
+		s.cols = append(s.cols, cols[p])
+	}
+
+	s.index = make(map[cOOKey]int, len(s.values))
+	for i := range s.values {
+		s.index[cOOKey{s.rows[i], s.cols[i]}] = i
+	}
+	s.sorted = true
+}
+
+// sort orders the triplets by row then column
+func (s *COOMatrix) sort() {
+	if s.sorted {
+		return
+	}
+
+	sort.Sort((*cOOMatrixRowMajor)(s))
+
+	for i := range s.values {
+		s.index[cOOKey{s.rows[i], s.cols[i]}] = i
+	}
+	s.sorted = true
+}
+
+type cOOMatrixRowMajor COOMatrix
+
+func (s *cOOMatrixRowMajor) Len() int {
+	return len(s.values)
+}
+
+func (s *cOOMatrixRowMajor) Less(i, j int) bool {
+	if s.rows[i] != s.rows[j] {
+		return s.rows[i] < s.rows[j]
+	}
+	return s.cols[i] < s.cols[j]
+}
+
+func (s *cOOMatrixRowMajor) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+}
+
+// ToCSRMatrix converts the matrix to compressed storage by rows
+func (s *COOMatrix) ToCSRMatrix() *CSRMatrix {
+	s.sort()
+
+	matrix := newCSRMatrix(s.r, s.c, len(s.values))
+	for i, r := range s.rows {
+		matrix.rowStart[r+1]++
+		matrix.cols[i] = s.cols[i]
+		matrix.values[i] = s.values[i]
+	}
+
+	for r := 0; r < s.r; r++ {
+		matrix.rowStart[r+1] += matrix.rowStart[r]
+	}
+
+	return matrix
+}
+
+// ToCSCMatrix converts the matrix to compressed storage by columns
+func (s *COOMatrix) ToCSCMatrix() *CSCMatrix {
+	s.sort()
+
+	matrix := newCSCMatrix(s.r, s.c, len(s.values))
+	for _, c := range s.cols {
+		matrix.colStart[c+1]++
+	}
+
+	for c := 0; c < s.c; c++ {
+		matrix.colStart[c+1] += matrix.colStart[c]
+	}
+
+	// walking the rows in order keeps the rows of each column sorted
+	next := make([]int, s.c)
+	copy(next, matrix.colStart[:s.c])
+	for i, c := range s.cols {
+		p := next[c]
+		matrix.rows[p] = s.rows[i]
+		matrix.values[p] = s.values[i]
+		next[c]++
+	}
+
+	return matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *COOMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *COOMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *COOMatrix) Update(r, c int, f func(float64) float64) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	if pointer, found := s.index[cOOKey{r, c}]; found {
+		s.values[pointer] = f(s.values[pointer])
+	} else {
+		s.insert(r, c, f(0))
+	}
+}
+
+func (s *COOMatrix) insert(r, c int, value float64) {
+	if l := len(s.values); l > 0 && (s.rows[l-1] > r || (s.rows[l-1] == r && s.cols[l-1] > c)) {
+		s.sorted = false
+	}
+
+	s.index[cOOKey{r, c}] = len(s.values)
+	s.values = append(s.values, value)
+	s.rows = append(s.rows, r)
+	s.cols = append(s.cols, c)
+}
+
+// remove moves the last triplet into the pointer
+func (s *COOMatrix) remove(pointer int) {
+	delete(s.index, cOOKey{s.rows[pointer], s.cols[pointer]})
+
+	last := len(s.values) - 1
+	if pointer != last {
+		s.values[pointer] = s.values[last]
+		s.rows[pointer] = s.rows[last]
+		s.cols[pointer] = s.cols[last]
+		s.index[cOOKey{s.rows[pointer], s.cols[pointer]}] = pointer
+		s.sorted = false
+	}
+
+	s.values = s.values[:last]
+	s.rows = s.rows[:last]
+	s.cols = s.cols[:last]
+}
+
+// pointer returns the position of the element at r-th, c-th
+func (s *COOMatrix) pointer(r, c int) (int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointer, found := s.index[cOOKey{r, c}]
+	return pointer, found
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *COOMatrix) At(r, c int) float64 {
+	if pointer, found := s.pointer(r, c); found {
+		return s.values[pointer]
+	}
+
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *COOMatrix) Has(r, c int) bool {
+	_, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *COOMatrix) Remove(r, c int) {
+	if pointer, found := s.pointer(r, c); found {
+		s.remove(pointer)
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *COOMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *COOMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+
+	for i := range s.values {
+		if s.cols[i] == c {
+			columns.SetVec(s.rows[i], s.values[i])
+		}
+	}
+
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *COOMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	s.row(r, func(c int, value float64) {
+		rows.SetVec(c, value)
+	})
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *COOMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+
+	s.row(r, func(c int, value float64) {
+		rows[c] = value
+	})
+
+	return rows
+}
+
+// row visits the elements of the r-th row, searching when the triplets are sorted
+func (s *COOMatrix) row(r int, f func(int, float64)) {
+	if s.sorted {
+		start := sort.SearchInts(s.rows, r)
+		for i := start; i < len(s.rows) && s.rows[i] == r; i++ {
+			f(s.cols[i], s.values[i])
+		}
+		return
+	}
+
+	for i := range s.values {
+		if s.rows[i] == r {
+			f(s.cols[i], s.values[i])
+		}
+	}
+}
+
+// Copy copies the matrix
+func (s *COOMatrix) Copy() Matrix {
+	matrix := newCOOMatrix(s.r, s.c, len(s.values))
+
+	copy(matrix.values, s.values)
+	copy(matrix.rows, s.rows)
+	copy(matrix.cols, s.cols)
+
+	for k, v := range s.index {
+		matrix.index[k] = v
+	}
+	matrix.sorted = s.sorted
+
+	return matrix
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *COOMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *COOMatrix) Multiply(m Matrix) Matrix {
+	matrix := newCOOMatrix(s.Rows(), m.Columns(), 0)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *COOMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *COOMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *COOMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *COOMatrix) Transpose() Matrix {
+	matrix := newCOOMatrix(s.c, s.r, len(s.values))
+
+	copy(matrix.values, s.values)
+	copy(matrix.rows, s.cols)
+	copy(matrix.cols, s.rows)
+
+	for i := range matrix.values {
+		matrix.index[cOOKey{matrix.rows[i], matrix.cols[i]}] = i
+	}
+	matrix.sorted = false
+
+	return matrix
+}
+
+// Equal the two matrices are equal
+func (s *COOMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *COOMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *COOMatrix) Size() int {
+	return s.Rows() * s.Columns()
+}
+
+// Values the number of stored elements in the matrix
+func (s *COOMatrix) Values() int {
+	return len(s.values)
+}
+
+// NVals the number of stored elements in the matrix
+func (s *COOMatrix) NVals() int {
+	return len(s.values)
+}
+
+// Clear removes all elements from a matrix
+func (s *COOMatrix) Clear() {
+	s.values = make([]float64, 0)
+	s.rows = make([]int, 0)
+	s.cols = make([]int, 0)
+	s.index = make(map[cOOKey]int)
+	s.sorted = true
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *COOMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+type cOOMatrixIterator struct {
+	matrix *COOMatrix
+	index  int
+}
+
+func (s *COOMatrix) iterator() *cOOMatrixIterator {
+	i := &cOOMatrixIterator{
+		matrix: s,
+	}
+	return i
+}
+
+// HasNext checks the iterator has any more values
+func (s *cOOMatrixIterator) HasNext() bool {
+	return s.index < len(s.matrix.values)
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *cOOMatrixIterator) Next() (int, int, float64) {
+	i := s.index
+	s.index++
+	return s.matrix.rows[i], s.matrix.cols[i], s.matrix.values[i]
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *COOMatrix) Map() Map {
+	t := s.iterator()
+	i := &cOOMatrixMap{t}
+	return i
+}
+
+type cOOMatrixMap struct {
+	*cOOMatrixIterator
+}
+
+// HasNext checks the iterator has any more values
+func (s *cOOMatrixMap) HasNext() bool {
+	return s.cOOMatrixIterator.HasNext()
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *cOOMatrixMap) Map(f func(int, int, float64) float64) {
+	i := s.index
+	s.matrix.values[i] = f(s.matrix.rows[i], s.matrix.cols[i], s.matrix.values[i])
+	s.index++
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *COOMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
@@ -38,7 +38,9 @@ func NewCSCMatrixFromArray(data [][]float64) *CSCMatrix {
 
 	for i := 0; i < r; i++ {
 		for k := 0; k < c; k++ {
-			s.Set(i, k, data[i][k])
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
 		}
 	}
 
@@ -80,25 +82,46 @@ func (s *CSCMatrix) Update(r, c int, f func(float64) float64) {
 	pointerStart, pointerEnd := s.rowIndex(r, c)
 
 	if pointerStart < pointerEnd && s.rows[pointerStart] == r {
-		value := f(s.values[pointerStart])
-		if value == 0 {
-			s.remove(pointerStart, c)
-		} else {
-			s.values[pointerStart] = value
-		}
+		s.values[pointerStart] = f(s.values[pointerStart])
 	} else {
 		s.insert(pointerStart, r, c, f(0))
 	}
 }
 
+// pointer returns the position of the element at r-th, c-th
+func (s *CSCMatrix) pointer(r, c int) (int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointerStart, pointerEnd := s.rowIndex(r, c)
+	return pointerStart, pointerStart < pointerEnd && s.rows[pointerStart] == r
+}
+
 // At returns the value of a matrix element at r-th, c-th
-func (s *CSCMatrix) At(r, c int) (value float64) {
-	s.Update(r, c, func(v float64) float64 {
-		value = v
-		return v
-	})
+func (s *CSCMatrix) At(r, c int) float64 {
+	if pointer, found := s.pointer(r, c); found {
+		return s.values[pointer]
+	}
 
-	return
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *CSCMatrix) Has(r, c int) bool {
+	_, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *CSCMatrix) Remove(r, c int) {
+	if pointer, found := s.pointer(r, c); found {
+		s.remove(pointer, c)
+	}
 }
 
 // Set sets the value at r-th, c-th of the matrix
@@ -163,10 +186,6 @@ func (s *CSCMatrix) RowsAtToArray(r int) []float64 {
 }
 
 func (s *CSCMatrix) insert(pointer, r, c int, value float64) {
-	if value == 0 {
-		return
-	}
-
 	s.rows = append(s.rows[:pointer], append([]int{r}, s.rows[pointer:]...)...)
 	s.values = append(s.values[:pointer], append([]float64{value}, s.values[pointer:]...)...)
 
@@ -248,7 +267,7 @@ func (s *CSCMatrix) Add(m Matrix) Matrix {
 
 // Subtract subtracts one matrix from another matrix
 func (s *CSCMatrix) Subtract(m Matrix) Matrix {
-	matrix := m.Copy()
+	matrix := s.Copy()
 	Subtract(context.Background(), s, m, nil, matrix)
 	return matrix
 }
@@ -283,11 +302,16 @@ func (s *CSCMatrix) Size() int {
 	return s.Rows() * s.Columns()
 }
 
-// Values the number of non-zero elements in the matrix
+// Values the number of stored elements in the matrix
 func (s *CSCMatrix) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the matrix
+func (s *CSCMatrix) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a matrix
 func (s *CSCMatrix) Clear() {
 	s.values = make([]float64, 0)
@@ -295,7 +319,7 @@ func (s *CSCMatrix) Clear() {
 	s.colStart = make([]int, s.c+1)
 }
 
-// Enumerate iterates through all non-zero elements, order is not guaranteed
+// Enumerate iterates through all stored elements, order is not guaranteed
 func (s *CSCMatrix) Enumerate() Enumerate {
 	return s.iterator()
 }
@@ -376,20 +400,10 @@ func (s *cSCMatrixMap) HasNext() bool {
 // Map move the iterator and uses a higher order function to changes the elements current value
 func (s *cSCMatrixMap) Map(f func(int, int, float64) float64) {
 	s.next()
-	value := f(s.r, s.c, s.matrix.values[s.index])
-	if value != 0 {
-		s.matrix.values[s.index] = value
-	} else {
-		s.matrix.remove(s.index, s.c)
-	}
+	s.matrix.values[s.index] = f(s.r, s.c, s.matrix.values[s.index])
 }
 
 // Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
-func (s *CSCMatrix) Element(r, c int) (b bool) {
-	s.Update(r, c, func(v float64) float64 {
-		b = v > 0
-		return v
-	})
-
-	return
+func (s *CSCMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
 }
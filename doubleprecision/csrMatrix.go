@@ -36,7 +36,9 @@ func NewCSRMatrixFromArray(data [][]float64) *CSRMatrix {
 	s := newCSRMatrix(r, c, 0)
 	for i := 0; i < r; i++ {
 		for k := 0; k < c; k++ {
-			s.Set(i, k, data[i][k])
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
 		}
 	}
 	return s
@@ -53,6 +55,21 @@ func newCSRMatrix(r, c int, l int) *CSRMatrix {
 	return s
 }
 
+// newCSRMatrixFromMatrix copies the elements of the matrix
+func newCSRMatrixFromMatrix(m Matrix) *CSRMatrix {
+	rows, cols, values := []int{}, []int{}, []float64{}
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		rows = append(rows, r)
+		cols = append(cols, c)
+		values = append(values, value)
+	}
+
+	coo := newCOOMatrix(m.Rows(), m.Columns(), 0)
+	coo.Build(rows, cols, values, nil)
+	return coo.ToCSRMatrix()
+}
+
 // Columns the number of columns of the matrix
 func (s *CSRMatrix) Columns() int {
 	return s.c
@@ -76,25 +93,46 @@ func (s *CSRMatrix) Update(r, c int, f func(float64) float64) {
 	pointerStart, pointerEnd := s.columnIndex(r, c)
 
 	if pointerStart < pointerEnd && s.cols[pointerStart] == c {
-		value := f(s.values[pointerStart])
-		if value == 0 {
-			s.remove(pointerStart, r)
-		} else {
-			s.values[pointerStart] = value
-		}
+		s.values[pointerStart] = f(s.values[pointerStart])
 	} else {
 		s.insert(pointerStart, r, c, f(0))
 	}
 }
 
+// pointer returns the position of the element at r-th, c-th
+func (s *CSRMatrix) pointer(r, c int) (int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointerStart, pointerEnd := s.columnIndex(r, c)
+	return pointerStart, pointerStart < pointerEnd && s.cols[pointerStart] == c
+}
+
 // At returns the value of a matrix element at r-th, c-th
-func (s *CSRMatrix) At(r, c int) (value float64) {
-	s.Update(r, c, func(v float64) float64 {
-		value = v
-		return v
-	})
+func (s *CSRMatrix) At(r, c int) float64 {
+	if pointer, found := s.pointer(r, c); found {
+		return s.values[pointer]
+	}
 
-	return
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *CSRMatrix) Has(r, c int) bool {
+	_, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *CSRMatrix) Remove(r, c int) {
+	if pointer, found := s.pointer(r, c); found {
+		s.remove(pointer, r)
+	}
 }
 
 // Set sets the value at r-th, c-th of the matrix
@@ -160,10 +198,6 @@ func (s *CSRMatrix) RowsAtToArray(r int) []float64 {
 }
 
 func (s *CSRMatrix) insert(pointer, r, c int, value float64) {
-	if value == 0 {
-		return
-	}
-
 	s.cols = append(s.cols[:pointer], append([]int{c}, s.cols[pointer:]...)...)
 	s.values = append(s.values[:pointer], append([]float64{value}, s.values[pointer:]...)...)
 
@@ -245,7 +279,7 @@ func (s *CSRMatrix) Add(m Matrix) Matrix {
 
 // Subtract subtracts one matrix from another matrix
 func (s *CSRMatrix) Subtract(m Matrix) Matrix {
-	matrix := m.Copy()
+	matrix := s.Copy()
 	Subtract(context.Background(), s, m, nil, matrix)
 	return matrix
 }
@@ -279,11 +313,16 @@ func (s *CSRMatrix) Size() int {
 	return s.Rows() * s.Columns()
 }
 
-// Values the number of non-zero elements in the matrix
+// Values the number of stored elements in the matrix
 func (s *CSRMatrix) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the matrix
+func (s *CSRMatrix) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a matrix
 func (s *CSRMatrix) Clear() {
 	s.values = make([]float64, 0)
@@ -291,7 +330,7 @@ func (s *CSRMatrix) Clear() {
 	s.rowStart = make([]int, s.r+1)
 }
 
-// Enumerate iterates through all non-zero elements, order is not guaranteed
+// Enumerate iterates through all stored elements, order is not guaranteed
 func (s *CSRMatrix) Enumerate() Enumerate {
 	return s.iterator()
 }
@@ -372,20 +411,10 @@ func (s *cSRMatrixMap) HasNext() bool {
 // Map move the iterator and uses a higher order function to changes the elements current value
 func (s *cSRMatrixMap) Map(f func(int, int, float64) float64) {
 	s.next()
-	value := f(s.r, s.c, s.matrix.values[s.index])
-	if value != 0 {
-		s.matrix.values[s.index] = value
-	} else {
-		s.matrix.remove(s.index, s.r)
-	}
+	s.matrix.values[s.index] = f(s.r, s.c, s.matrix.values[s.index])
 }
 
 // Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
-func (s *CSRMatrix) Element(r, c int) (b bool) {
-	s.Update(r, c, func(v float64) float64 {
-		b = v > 0
-		return v
-	})
-
-	return
+func (s *CSRMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
 }
@@ -0,0 +1,422 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*DCSCMatrix)(nil)).Elem())
+}
+
+// DCSCMatrix doubly compressed storage by columns (DCSC), only the non-empty columns are stored
+type DCSCMatrix struct {
+	r        int // number of rows in the sparse matrix
+	c        int // number of columns in the sparse matrix
+	values   []float64
+	rows     []int
+	cols     []int // the non-empty columns in order
+	colStart []int // the start of each non-empty column, len(cols)+1
+}
+
+// NewDCSCMatrix returns a DCSCMatrix
+func NewDCSCMatrix(r, c int) *DCSCMatrix {
+	return newDCSCMatrix(r, c, 0, 0)
+}
+
+// NewDCSCMatrixFromArray returns a DCSCMatrix
+func NewDCSCMatrixFromArray(data [][]float64) *DCSCMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := newDCSCMatrix(r, c, 0, 0)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+func newDCSCMatrix(r, c int, l, nc int) *DCSCMatrix {
+	s := &DCSCMatrix{
+		r:        r,
+		c:        c,
+		values:   make([]float64, l),
+		rows:     make([]int, l),
+		cols:     make([]int, nc),
+		colStart: make([]int, nc+1),
+	}
+	return s
+}
+
+// ToDCSCMatrix converts the matrix to doubly compressed storage by columns
+func (s *CSCMatrix) ToDCSCMatrix() *DCSCMatrix {
+	matrix := newDCSCMatrix(s.r, s.c, len(s.values), 0)
+	copy(matrix.values, s.values)
+	copy(matrix.rows, s.rows)
+
+	for c := 0; c < s.c; c++ {
+		if s.colStart[c] < s.colStart[c+1] {
+			matrix.cols = append(matrix.cols, c)
+			matrix.colStart = append(matrix.colStart, s.colStart[c+1])
+		}
+	}
+
+	return matrix
+}
+
+// ToCSCMatrix converts the matrix to compressed storage by columns
+func (s *DCSCMatrix) ToCSCMatrix() *CSCMatrix {
+	matrix := newCSCMatrix(s.r, s.c, len(s.values))
+	copy(matrix.values, s.values)
+	copy(matrix.rows, s.rows)
+
+	for p, c := range s.cols {
+		matrix.colStart[c+1] = s.colStart[p+1] - s.colStart[p]
+	}
+
+	for c := 0; c < s.c; c++ {
+		matrix.colStart[c+1] += matrix.colStart[c]
+	}
+
+	return matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *DCSCMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *DCSCMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *DCSCMatrix) Update(r, c int, f func(float64) float64) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	p, found := s.columnIndex(c)
+	if !found {
+		s.insert(p, false, c, s.colStart[p], r, f(0))
+		return
+	}
+
+	pointerStart, pointerEnd := s.rowIndex(p, r)
+
+	if pointerStart < pointerEnd && s.rows[pointerStart] == r {
+		s.values[pointerStart] = f(s.values[pointerStart])
+	} else {
+		s.insert(p, true, c, pointerStart, r, f(0))
+	}
+}
+
+// pointer returns the position of the element at r-th, c-th and of its column
+func (s *DCSCMatrix) pointer(r, c int) (int, int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	p, found := s.columnIndex(c)
+	if !found {
+		return p, 0, false
+	}
+
+	pointerStart, pointerEnd := s.rowIndex(p, r)
+	return p, pointerStart, pointerStart < pointerEnd && s.rows[pointerStart] == r
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *DCSCMatrix) At(r, c int) float64 {
+	if _, pointer, found := s.pointer(r, c); found {
+		return s.values[pointer]
+	}
+
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *DCSCMatrix) Has(r, c int) bool {
+	_, _, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *DCSCMatrix) Remove(r, c int) {
+	if p, pointer, found := s.pointer(r, c); found {
+		s.remove(p, pointer)
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *DCSCMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *DCSCMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+
+	if p, found := s.columnIndex(c); found {
+		for i := s.colStart[p]; i < s.colStart[p+1]; i++ {
+			columns.SetVec(s.rows[i], s.values[i])
+		}
+	}
+
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *DCSCMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	for p, c := range s.cols {
+		pointerStart, pointerEnd := s.rowIndex(p, r)
+		if pointerStart < pointerEnd && s.rows[pointerStart] == r {
+			rows.SetVec(c, s.values[pointerStart])
+		}
+	}
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *DCSCMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+
+	for p, c := range s.cols {
+		pointerStart, pointerEnd := s.rowIndex(p, r)
+		if pointerStart < pointerEnd && s.rows[pointerStart] == r {
+			rows[c] = s.values[pointerStart]
+		}
+	}
+
+	return rows
+}
+
+// columnIndex the position of the c-th column in the non-empty columns or where it would be inserted
+func (s *DCSCMatrix) columnIndex(c int) (int, bool) {
+	p := sort.SearchInts(s.cols, c)
+	return p, p < len(s.cols) && s.cols[p] == c
+}
+
+func (s *DCSCMatrix) rowIndex(p, r int) (int, int) {
+	start := s.colStart[p]
+	end := s.colStart[p+1]
+
+	i := start + sort.SearchInts(s.rows[start:end], r)
+	return i, end
+}
+
+func (s *DCSCMatrix) insert(p int, found bool, c, pointer, r int, value float64) {
+	if !found {
+		s.cols = append(s.cols[:p], append([]int{c}, s.cols[p:]...)...)
+		s.colStart = append(s.colStart[:p], append([]int{s.colStart[p]}, s.colStart[p:]...)...)
+	}
+
+	s.rows = append(s.rows[:pointer], append([]int{r}, s.rows[pointer:]...)...)
+	s.values = append(s.values[:pointer], append([]float64{value}, s.values[pointer:]...)...)
+
+	for i := p + 1; i < len(s.colStart); i++ {
+		s.colStart[i]++
+	}
+}
+
+func (s *DCSCMatrix) remove(p, pointer int) {
+	s.rows = append(s.rows[:pointer], s.rows[pointer+1:]...)
+	s.values = append(s.values[:pointer], s.values[pointer+1:]...)
+
+	for i := p + 1; i < len(s.colStart); i++ {
+		s.colStart[i]--
+	}
+
+	if s.colStart[p] == s.colStart[p+1] {
+		s.cols = append(s.cols[:p], s.cols[p+1:]...)
+		s.colStart = append(s.colStart[:p], s.colStart[p+1:]...)
+	}
+}
+
+// Copy copies the matrix
+func (s *DCSCMatrix) Copy() Matrix {
+	matrix := newDCSCMatrix(s.r, s.c, len(s.values), len(s.cols))
+
+	copy(matrix.values, s.values)
+	copy(matrix.rows, s.rows)
+	copy(matrix.cols, s.cols)
+	copy(matrix.colStart, s.colStart)
+
+	return matrix
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *DCSCMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *DCSCMatrix) Multiply(m Matrix) Matrix {
+	matrix := newDCSCMatrix(s.Rows(), m.Columns(), 0, 0)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *DCSCMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *DCSCMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *DCSCMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *DCSCMatrix) Transpose() Matrix {
+	matrix := newDCSCMatrix(s.c, s.r, 0, 0)
+	matrix.values, matrix.rows, matrix.cols, matrix.colStart = doublyCompressedTranspose(s.cols, s.colStart, s.rows, s.values)
+	return matrix
+}
+
+// Equal the two matrices are equal
+func (s *DCSCMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *DCSCMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix, saturates at the largest int when rows × columns overflows
+func (s *DCSCMatrix) Size() int {
+	return size(s.Rows(), s.Columns())
+}
+
+// Values the number of stored elements in the matrix
+func (s *DCSCMatrix) Values() int {
+	return len(s.values)
+}
+
+// NVals the number of stored elements in the matrix
+func (s *DCSCMatrix) NVals() int {
+	return len(s.values)
+}
+
+// Clear removes all elements from a matrix
+func (s *DCSCMatrix) Clear() {
+	s.values = make([]float64, 0)
+	s.rows = make([]int, 0)
+	s.cols = make([]int, 0)
+	s.colStart = make([]int, 1)
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *DCSCMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+type dCSCMatrixIterator struct {
+	matrix *DCSCMatrix
+	p      int
+	index  int
+}
+
+func (s *DCSCMatrix) iterator() *dCSCMatrixIterator {
+	i := &dCSCMatrixIterator{
+		matrix: s,
+	}
+	return i
+}
+
+// next moves to the non-empty column holding the index
+func (s *dCSCMatrixIterator) next() {
+	for s.matrix.colStart[s.p+1] <= s.index {
+		s.p++
+	}
+}
+
+// HasNext checks the iterator has any more values
+func (s *dCSCMatrixIterator) HasNext() bool {
+	return s.index < len(s.matrix.values)
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *dCSCMatrixIterator) Next() (int, int, float64) {
+	s.next()
+	i := s.index
+	s.index++
+	return s.matrix.rows[i], s.matrix.cols[s.p], s.matrix.values[i]
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *DCSCMatrix) Map() Map {
+	t := s.iterator()
+	i := &dCSCMatrixMap{t}
+	return i
+}
+
+type dCSCMatrixMap struct {
+	*dCSCMatrixIterator
+}
+
+// HasNext checks the iterator has any more values
+func (s *dCSCMatrixMap) HasNext() bool {
+	return s.dCSCMatrixIterator.HasNext()
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *dCSCMatrixMap) Map(f func(int, int, float64) float64) {
+	s.next()
+	i := s.index
+	s.matrix.values[i] = f(s.matrix.rows[i], s.matrix.cols[s.p], s.matrix.values[i])
+	s.index++
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *DCSCMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
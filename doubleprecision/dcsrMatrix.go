@@ -0,0 +1,551 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*DCSRMatrix)(nil)).Elem())
+}
+
+// DCSRMatrix doubly compressed storage by rows (DCSR), only the non-empty rows are stored
+type DCSRMatrix struct {
+	r        int // number of rows in the sparse matrix
+	c        int // number of columns in the sparse matrix
+	values   []float64
+	cols     []int
+	rows     []int // the non-empty rows in order
+	rowStart []int // the start of each non-empty row, len(rows)+1
+}
+
+// NewDCSRMatrix returns a DCSRMatrix
+func NewDCSRMatrix(r, c int) *DCSRMatrix {
+	return newDCSRMatrix(r, c, 0, 0)
+}
+
+// NewDCSRMatrixFromArray returns a DCSRMatrix
+func NewDCSRMatrixFromArray(data [][]float64) *DCSRMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := newDCSRMatrix(r, c, 0, 0)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+func newDCSRMatrix(r, c int, l, nr int) *DCSRMatrix {
+	s := &DCSRMatrix{
+		r:        r,
+		c:        c,
+		values:   make([]float64, l),
+		cols:     make([]int, l),
+		rows:     make([]int, nr),
+		rowStart: make([]int, nr+1),
+	}
+	return s
+}
+
+// ToDCSRMatrix converts the matrix to doubly compressed storage by rows
+func (s *CSRMatrix) ToDCSRMatrix() *DCSRMatrix {
+	matrix := newDCSRMatrix(s.r, s.c, len(s.values), 0)
+	copy(matrix.values, s.values)
+	copy(matrix.cols, s.cols)
+
+	for r := 0; r < s.r; r++ {
+		if s.rowStart[r] < s.rowStart[r+1] {
+			matrix.rows = append(matrix.rows, r)
+			matrix.rowStart = append(matrix.rowStart, s.rowStart[r+1])
+		}
+	}
+
+	return matrix
+}
+
+// ToCSRMatrix converts the matrix to compressed storage by rows
+func (s *DCSRMatrix) ToCSRMatrix() *CSRMatrix {
+	matrix := newCSRMatrix(s.r, s.c, len(s.values))
+	copy(matrix.values, s.values)
+	copy(matrix.cols, s.cols)
+
+	for p, r := range s.rows {
+		matrix.rowStart[r+1] = s.rowStart[p+1] - s.rowStart[p]
+	}
+
+	for r := 0; r < s.r; r++ {
+		matrix.rowStart[r+1] += matrix.rowStart[r]
+	}
+
+	return matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *DCSRMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *DCSRMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *DCSRMatrix) Update(r, c int, f func(float64) float64) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	p, found := s.rowIndex(r)
+	if !found {
+		s.insert(p, false, r, s.rowStart[p], c, f(0))
+		return
+	}
+
+	pointerStart, pointerEnd := s.columnIndex(p, c)
+
+	if pointerStart < pointerEnd && s.cols[pointerStart] == c {
+		s.values[pointerStart] = f(s.values[pointerStart])
+	} else {
+		s.insert(p, true, r, pointerStart, c, f(0))
+	}
+}
+
+// pointer returns the position of the element at r-th, c-th and of its row
+func (s *DCSRMatrix) pointer(r, c int) (int, int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	p, found := s.rowIndex(r)
+	if !found {
+		return p, 0, false
+	}
+
+	pointerStart, pointerEnd := s.columnIndex(p, c)
+	return p, pointerStart, pointerStart < pointerEnd && s.cols[pointerStart] == c
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *DCSRMatrix) At(r, c int) float64 {
+	if _, pointer, found := s.pointer(r, c); found {
+		return s.values[pointer]
+	}
+
+	return 0
+}
+
+// Has the element at r-th, c-th is stored
+func (s *DCSRMatrix) Has(r, c int) bool {
+	_, _, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *DCSRMatrix) Remove(r, c int) {
+	if p, pointer, found := s.pointer(r, c); found {
+		s.remove(p, pointer)
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *DCSRMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *DCSRMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+
+	for p, r := range s.rows {
+		pointerStart, pointerEnd := s.columnIndex(p, c)
+		if pointerStart < pointerEnd && s.cols[pointerStart] == c {
+			columns.SetVec(r, s.values[pointerStart])
+		}
+	}
+
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *DCSRMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	if p, found := s.rowIndex(r); found {
+		for i := s.rowStart[p]; i < s.rowStart[p+1]; i++ {
+			rows.SetVec(s.cols[i], s.values[i])
+		}
+	}
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *DCSRMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+
+	if p, found := s.rowIndex(r); found {
+		for i := s.rowStart[p]; i < s.rowStart[p+1]; i++ {
+			rows[s.cols[i]] = s.values[i]
+		}
+	}
+
+	return rows
+}
+
+// rowIndex the position of the r-th row in the non-empty rows or where it would be inserted
+func (s *DCSRMatrix) rowIndex(r int) (int, bool) {
+	p := sort.SearchInts(s.rows, r)
+	return p, p < len(s.rows) && s.rows[p] == r
+}
+
+func (s *DCSRMatrix) columnIndex(p, c int) (int, int) {
+	start := s.rowStart[p]
+	end := s.rowStart[p+1]
+
+	i := start + sort.SearchInts(s.cols[start:end], c)
+	return i, end
+}
+
+func (s *DCSRMatrix) insert(p int, found bool, r, pointer, c int, value float64) {
+	if !found {
+		s.rows = append(s.rows[:p], append([]int{r}, s.rows[p:]...)...)
+		s.rowStart = append(s.rowStart[:p], append([]int{s.rowStart[p]}, s.rowStart[p:]...)...)
+	}
+
+	s.cols = append(s.cols[:pointer], append([]int{c}, s.cols[pointer:]...)...)
+	s.values = append(s.values[:pointer], append([]float64{value}, s.values[pointer:]...)...)
+
+	for i := p + 1; i < len(s.rowStart); i++ {
+		s.rowStart[i]++
+	}
+}
+
+func (s *DCSRMatrix) remove(p, pointer int) {
+	s.cols = append(s.cols[:pointer], s.cols[pointer+1:]...)
+	s.values = append(s.values[:pointer], s.values[pointer+1:]...)
+
+	for i := p + 1; i < len(s.rowStart); i++ {
+		s.rowStart[i]--
+	}
+
+	if s.rowStart[p] == s.rowStart[p+1] {
+		s.rows = append(s.rows[:p], s.rows[p+1:]...)
+		s.rowStart = append(s.rowStart[:p], s.rowStart[p+1:]...)
+	}
+}
+
+// Copy copies the matrix
+func (s *DCSRMatrix) Copy() Matrix {
+	matrix := newDCSRMatrix(s.r, s.c, len(s.values), len(s.rows))
+
+	copy(matrix.values, s.values)
+	copy(matrix.cols, s.cols)
+	copy(matrix.rows, s.rows)
+	copy(matrix.rowStart, s.rowStart)
+
+	return matrix
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *DCSRMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *DCSRMatrix) Multiply(m Matrix) Matrix {
+	matrix := newDCSRMatrix(s.Rows(), m.Columns(), 0, 0)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// multiply the matrix by another matrix (or vector) visiting only the non-empty rows
+// each row is accumulated from the rows of m it selects (Gustavson) so the work depends on the stored elements and not the dimensions
+func (s *DCSRMatrix) multiply(ctx context.Context, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
+	if m.Rows() != s.c {
+		log.Panicf("Can not multiply matrices found length mismatch %+v, %+v", m.Rows(), s.c)
+	}
+
+	if mask == nil {
+		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
+	}
+
+	if mask.Rows() != matrix.Rows() {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
+	}
+
+	if mask.Columns() != matrix.Columns() {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
+	}
+
+	rowsOf := map[int]Vector{}
+	result := make(map[int]map[int]float64, len(s.rows))
+	for p, r := range s.rows {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		sums := map[int]float64{}
+		for i := s.rowStart[p]; i < s.rowStart[p+1]; i++ {
+			k := s.cols[i]
+			row, ok := rowsOf[k]
+			if !ok {
+				row = m.RowsAt(k)
+				rowsOf[k] = row
+			}
+
+			for iterator := row.Enumerate(); iterator.HasNext(); {
+				c, _, value := iterator.Next()
+				sums[c] += s.values[i] * value
+			}
+		}
+
+		if len(sums) > 0 {
+			result[r] = sums
+		}
+	}
+
+	// an element is only stored when a pair of stored elements meet
+	removed := [][2]int{}
+	for iterator := matrix.Enumerate(); iterator.HasNext(); {
+		r, c, _ := iterator.Next()
+		if _, ok := result[r][c]; !ok && !mask.Element(r, c) {
+			removed = append(removed, [2]int{r, c})
+		}
+	}
+	for _, e := range removed {
+		matrix.Remove(e[0], e[1])
+	}
+
+	for _, r := range s.rows {
+		sums, ok := result[r]
+		if !ok {
+			continue
+		}
+
+		cols := make([]int, 0, len(sums))
+		for c := range sums {
+			cols = append(cols, c)
+		}
+		sort.Ints(cols)
+
+		for _, c := range cols {
+			if !mask.Element(r, c) {
+				matrix.Set(r, c, sums[c])
+			}
+		}
+	}
+}
+
+// Add addition of a matrix by another matrix
+func (s *DCSRMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *DCSRMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *DCSRMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *DCSRMatrix) Transpose() Matrix {
+	matrix := newDCSRMatrix(s.c, s.r, 0, 0)
+	matrix.values, matrix.cols, matrix.rows, matrix.rowStart = doublyCompressedTranspose(s.rows, s.rowStart, s.cols, s.values)
+	return matrix
+}
+
+// doublyCompressedTranspose swaps the major and minor index of doubly compressed storage
+func doublyCompressedTranspose(major, start, minor []int, values []float64) ([]float64, []int, []int, []int) {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+
+	// the entries are in major order already so a stable sort by minor keeps each new row sorted
+	sort.SliceStable(order, func(i, j int) bool {
+		return minor[order[i]] < minor[order[j]]
+	})
+
+	majorOf := make([]int, len(values))
+	for p := range major {
+		for i := start[p]; i < start[p+1]; i++ {
+			majorOf[i] = major[p]
+		}
+	}
+
+	tValues := make([]float64, len(values))
+	tMinor := make([]int, len(values))
+	tMajor := []int{}
+	tStart := []int{0}
+
+	for i, k := range order {
+		tValues[i] = values[k]
+		tMinor[i] = majorOf[k]
+
+		if i == 0 || minor[k] != minor[order[i-1]] {
+			tMajor = append(tMajor, minor[k])
+			tStart = append(tStart, i+1)
+		} else {
+			tStart[len(tStart)-1]++
+		}
+	}
+
+	return tValues, tMinor, tMajor, tStart
+}
+
+// Equal the two matrices are equal
+func (s *DCSRMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *DCSRMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix, saturates at the largest int when rows × columns overflows
+func (s *DCSRMatrix) Size() int {
+	return size(s.Rows(), s.Columns())
+}
+
+// size rows × columns or the largest int when the product overflows, a hypersparse matrix can have more elements than an int can count
+func size(r, c int) int {
+	max := int(^uint(0) >> 1)
+	if r != 0 && c > max/r {
+		return max
+	}
+	return r * c
+}
+
+// Values the number of stored elements in the matrix
+func (s *DCSRMatrix) Values() int {
+	return len(s.values)
+}
+
+// NVals the number of stored elements in the matrix
+func (s *DCSRMatrix) NVals() int {
+	return len(s.values)
+}
+
+// Clear removes all elements from a matrix
+func (s *DCSRMatrix) Clear() {
+	s.values = make([]float64, 0)
+	s.cols = make([]int, 0)
+	s.rows = make([]int, 0)
+	s.rowStart = make([]int, 1)
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *DCSRMatrix) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+type dCSRMatrixIterator struct {
+	matrix *DCSRMatrix
+	p      int
+	index  int
+}
+
+func (s *DCSRMatrix) iterator() *dCSRMatrixIterator {
+	i := &dCSRMatrixIterator{
+		matrix: s,
+	}
+	return i
+}
+
+// next moves to the non-empty row holding the index
+func (s *dCSRMatrixIterator) next() {
+	for s.matrix.rowStart[s.p+1] <= s.index {
+		s.p++
+	}
+}
+
+// HasNext checks the iterator has any more values
+func (s *dCSRMatrixIterator) HasNext() bool {
+	return s.index < len(s.matrix.values)
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *dCSRMatrixIterator) Next() (int, int, float64) {
+	s.next()
+	i := s.index
+	s.index++
+	return s.matrix.rows[s.p], s.matrix.cols[i], s.matrix.values[i]
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *DCSRMatrix) Map() Map {
+	t := s.iterator()
+	i := &dCSRMatrixMap{t}
+	return i
+}
+
+type dCSRMatrixMap struct {
+	*dCSRMatrixIterator
+}
+
+// HasNext checks the iterator has any more values
+func (s *dCSRMatrixMap) HasNext() bool {
+	return s.dCSRMatrixIterator.HasNext()
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *dCSRMatrixMap) Map(f func(int, int, float64) float64) {
+	s.next()
+	i := s.index
+	s.matrix.values[i] = f(s.matrix.rows[s.p], s.matrix.cols[i], s.matrix.values[i])
+	s.index++
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *DCSRMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
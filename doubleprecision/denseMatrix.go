@@ -10,11 +10,14 @@ import (
 	"log"
 )
 
-// DenseMatrix a dense matrix
+// DenseMatrix a dense matrix, the storage is full and a bitset marks the stored elements,
+// a new matrix stores every element and Remove or Clear leave a missing element as zero
 type DenseMatrix struct {
-	c    int // number of rows in the sparse matrix
-	r    int // number of columns in the sparse matrix
-	data [][]float64
+	c       int // number of rows in the sparse matrix
+	r       int // number of columns in the sparse matrix
+	data    [][]float64
+	present []uint64 // the stored elements, nil when every element is stored
+	count   int      // number of stored elements when present is not nil
 }
 
 // NewDenseMatrix returns a DenseMatrix
@@ -45,6 +48,25 @@ func newMatrix(r, c int, initialise func([]float64, int)) *DenseMatrix {
 	return s
 }
 
+func (s *DenseMatrix) has(r, c int) bool {
+	if s.present == nil {
+		return true
+	}
+
+	i := r*s.c + c
+	return s.present[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (s *DenseMatrix) store(r, c int) {
+	if s.present == nil || s.has(r, c) {
+		return
+	}
+
+	i := r*s.c + c
+	s.present[i/64] |= 1 << uint(i%64)
+	s.count++
+}
+
 // Columns the number of columns of the matrix
 func (s *DenseMatrix) Columns() int {
 	return s.c
@@ -66,6 +88,7 @@ func (s *DenseMatrix) Update(r, c int, f func(float64) float64) {
 	}
 
 	s.data[r][c] = f(s.data[r][c])
+	s.store(r, c)
 
 	return
 }
@@ -83,6 +106,47 @@ func (s *DenseMatrix) At(r, c int) float64 {
 	return s.data[r][c]
 }
 
+// Has the element at r-th, c-th is stored
+func (s *DenseMatrix) Has(r, c int) bool {
+	if r < 0 || r >= s.Rows() {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return s.has(r, c)
+}
+
+// Remove the element at r-th, c-th, the missing element reads as zero
+func (s *DenseMatrix) Remove(r, c int) {
+	if r < 0 || r >= s.Rows() {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	if !s.has(r, c) {
+		return
+	}
+
+	if s.present == nil {
+		s.present = make([]uint64, (s.r*s.c+63)/64)
+		for i := 0; i < s.r*s.c; i++ {
+			s.present[i/64] |= 1 << uint(i%64)
+		}
+		s.count = s.r * s.c
+	}
+
+	i := r*s.c + c
+	s.present[i/64] &^= 1 << uint(i%64)
+	s.count--
+	s.data[r][c] = 0
+}
+
 // Set sets the value at r-th, c-th of the matrix
 func (s *DenseMatrix) Set(r, c int, value float64) {
 	if r < 0 || r >= s.Rows() {
@@ -94,6 +158,7 @@ func (s *DenseMatrix) Set(r, c int, value float64) {
 	}
 
 	s.data[r][c] = value
+	s.store(r, c)
 }
 
 // ColumnsAt return the columns at c-th
@@ -143,19 +208,15 @@ func (s *DenseMatrix) RowsAtToArray(r int) []float64 {
 
 // Copy copies the matrix
 func (s *DenseMatrix) Copy() Matrix {
-	v := 0.0
-
 	matrix := newMatrix(s.Rows(), s.Columns(), func(row []float64, r int) {
-		for c := 0; c < s.Columns(); c++ {
-			v = s.data[r][c]
-			if v != 0.0 {
-				row[c] = v
-			} else {
-				row[c] = v
-			}
-		}
+		copy(row, s.data[r])
 	})
 
+	if s.present != nil {
+		matrix.present = append([]uint64(nil), s.present...)
+		matrix.count = s.count
+	}
+
 	return matrix
 }
 
@@ -180,7 +241,7 @@ func (s *DenseMatrix) Add(m Matrix) Matrix {
 
 // Subtract subtracts one matrix from another matrix
 func (s *DenseMatrix) Subtract(m Matrix) Matrix {
-	matrix := m.Copy()
+	matrix := s.Copy()
 	Subtract(context.Background(), s, m, nil, matrix)
 	return matrix
 }
@@ -216,7 +277,15 @@ func (s *DenseMatrix) Size() int {
 
 // Values the number of elements in the matrix
 func (s *DenseMatrix) Values() int {
-	return s.r * s.c
+	return s.NVals()
+}
+
+// NVals the number of stored elements in the matrix
+func (s *DenseMatrix) NVals() int {
+	if s.present == nil {
+		return s.r * s.c
+	}
+	return s.count
 }
 
 // Clear removes all elements from a matrix
@@ -225,6 +294,8 @@ func (s *DenseMatrix) Clear() {
 	for i := 0; i < s.r; i++ {
 		s.data[i] = make([]float64, s.c)
 	}
+	s.present = make([]uint64, (s.r*s.c+63)/64)
+	s.count = 0
 }
 
 // RawMatrix returns the raw matrix
@@ -232,7 +303,7 @@ func (s *DenseMatrix) RawMatrix() [][]float64 {
 	return s.data
 }
 
-// Enumerate iterates through all non-zero elements, order is not guaranteed
+// Enumerate iterates through all stored elements, order is not guaranteed
 func (s *DenseMatrix) Enumerate() Enumerate {
 	return s.iterator()
 }
@@ -240,7 +311,7 @@ func (s *DenseMatrix) Enumerate() Enumerate {
 func (s *DenseMatrix) iterator() *denseMatrixIterator {
 	i := &denseMatrixIterator{
 		matrix: s,
-		size:   s.Values(),
+		size:   s.r * s.c,
 		last:   0,
 		c:      0,
 		r:      0,
@@ -254,12 +325,14 @@ type denseMatrixIterator struct {
 	last   int
 	c      int
 	r      int
-	cOld   int
-	rOld   int
 }
 
 // HasNext checks the iterator has any more values
 func (s *denseMatrixIterator) HasNext() bool {
+	for s.last < s.size && !s.matrix.has(s.last/s.matrix.c, s.last%s.matrix.c) {
+		s.last++
+	}
+
 	if s.last >= s.size {
 		return false
 	}
@@ -267,12 +340,9 @@ func (s *denseMatrixIterator) HasNext() bool {
 }
 
 func (s *denseMatrixIterator) next() {
-	if s.c == s.matrix.Columns() {
-		s.c = 0
-		s.r++
-	}
-	s.cOld = s.c
-	s.c++
+	s.HasNext()
+	s.r = s.last / s.matrix.c
+	s.c = s.last % s.matrix.c
 	s.last++
 }
 
@@ -280,7 +350,7 @@ func (s *denseMatrixIterator) next() {
 func (s *denseMatrixIterator) Next() (int, int, float64) {
 	s.next()
 
-	return s.r, s.cOld, s.matrix.At(s.r, s.cOld)
+	return s.r, s.c, s.matrix.At(s.r, s.c)
 }
 
 // Map replace each element with the result of applying a function to its value
@@ -303,7 +373,7 @@ func (s *denseMatrixMap) HasNext() bool {
 func (s *denseMatrixMap) Map(f func(int, int, float64) float64) {
 	s.next()
 
-	s.matrix.Set(s.r, s.cOld, f(s.r, s.cOld, s.matrix.At(s.r, s.cOld)))
+	s.matrix.Set(s.r, s.c, f(s.r, s.c, s.matrix.At(s.r, s.c)))
 }
 
 // Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
@@ -0,0 +1,271 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*DiagonalMatrix)(nil)).Elem())
+}
+
+// DiagonalMatrix a matrix with all elements off the main diagonal zero, the whole diagonal is stored
+type DiagonalMatrix struct {
+	r      int // number of rows in the matrix
+	c      int // number of columns in the matrix
+	values []float64
+}
+
+// NewDiagonalMatrix returns a DiagonalMatrix
+func NewDiagonalMatrix(r, c int) *DiagonalMatrix {
+	n := r
+	if c < n {
+		n = c
+	}
+	return &DiagonalMatrix{r: r, c: c, values: make([]float64, n)}
+}
+
+// NewDiagonalMatrixFromArray returns a square DiagonalMatrix with the values on the diagonal
+func NewDiagonalMatrixFromArray(data []float64) *DiagonalMatrix {
+	values := make([]float64, len(data))
+	copy(values, data)
+	return &DiagonalMatrix{r: len(data), c: len(data), values: values}
+}
+
+// Diagonal returns the values on the diagonal
+func (s *DiagonalMatrix) Diagonal() []float64 {
+	return s.values
+}
+
+func (s *DiagonalMatrix) checkBounds(r, c int) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+}
+
+// Columns the number of columns of the matrix
+func (s *DiagonalMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *DiagonalMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *DiagonalMatrix) Update(r, c int, f func(float64) float64) {
+	s.checkBounds(r, c)
+	if r != c {
+		log.Panicf("Element '%+v, %+v' is off the diagonal", r, c)
+	}
+	s.values[r] = f(s.values[r])
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *DiagonalMatrix) At(r, c int) float64 {
+	s.checkBounds(r, c)
+	if r != c {
+		return 0
+	}
+	return s.values[r]
+}
+
+// Has the element at r-th, c-th is stored, the whole diagonal is stored
+func (s *DiagonalMatrix) Has(r, c int) bool {
+	return r == c && r >= 0 && r < len(s.values)
+}
+
+// Remove sets the element on the diagonal at r-th, c-th to zero
+func (s *DiagonalMatrix) Remove(r, c int) {
+	s.checkBounds(r, c)
+	if r == c {
+		s.values[r] = 0
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *DiagonalMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *DiagonalMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+	if c < len(s.values) {
+		columns.SetVec(c, s.values[c])
+	}
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *DiagonalMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+	if r < len(s.values) {
+		rows.SetVec(r, s.values[r])
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *DiagonalMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+	if r < len(s.values) {
+		rows[r] = s.values[r]
+	}
+	return rows
+}
+
+// Copy copies the matrix
+func (s *DiagonalMatrix) Copy() Matrix {
+	values := make([]float64, len(s.values))
+	copy(values, s.values)
+	return &DiagonalMatrix{r: s.r, c: s.c, values: values}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *DiagonalMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix, the product of two diagonal matrices is diagonal
+func (s *DiagonalMatrix) Multiply(m Matrix) Matrix {
+	if s.Columns() != m.Rows() {
+		log.Panicf("Can not multiply matrices found length miss match %+v, %+v", s.Columns(), m.Rows())
+	}
+
+	if diagonal, ok := m.(*DiagonalMatrix); ok {
+		matrix := NewDiagonalMatrix(s.r, diagonal.c)
+		for i := range matrix.values {
+			matrix.values[i] = s.values[i] * diagonal.values[i]
+		}
+		return matrix
+	}
+
+	matrix := NewCSRMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix, the result is a CSRMatrix
+func (s *DiagonalMatrix) Add(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix, the result is a CSRMatrix
+func (s *DiagonalMatrix) Subtract(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix, the result is a CSRMatrix
+func (s *DiagonalMatrix) Negative() Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *DiagonalMatrix) Transpose() Matrix {
+	values := make([]float64, len(s.values))
+	copy(values, s.values)
+	return &DiagonalMatrix{r: s.c, c: s.r, values: values}
+}
+
+// Equal the two matrices are equal
+func (s *DiagonalMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *DiagonalMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *DiagonalMatrix) Size() int {
+	return s.r * s.c
+}
+
+// Values the number of elements on the diagonal
+func (s *DiagonalMatrix) Values() int {
+	return len(s.values)
+}
+
+// NVals the number of elements on the diagonal
+func (s *DiagonalMatrix) NVals() int {
+	return len(s.values)
+}
+
+// Clear sets all elements on the diagonal to zero
+func (s *DiagonalMatrix) Clear() {
+	for i := range s.values {
+		s.values[i] = 0
+	}
+}
+
+// Enumerate iterates through all elements on the diagonal
+func (s *DiagonalMatrix) Enumerate() Enumerate {
+	return &diagonalMatrixIterator{matrix: s}
+}
+
+// Map replace each element on the diagonal with the result of applying a function to its value
+func (s *DiagonalMatrix) Map() Map {
+	return &diagonalMatrixIterator{matrix: s}
+}
+
+type diagonalMatrixIterator struct {
+	matrix *DiagonalMatrix
+	index  int
+}
+
+// HasNext checks the iterator has any more values
+func (s *diagonalMatrixIterator) HasNext() bool {
+	return s.index < len(s.matrix.values)
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *diagonalMatrixIterator) Next() (int, int, float64) {
+	i := s.index
+	s.index++
+	return i, i, s.matrix.values[i]
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *diagonalMatrixIterator) Map(f func(int, int, float64) float64) {
+	i := s.index
+	s.index++
+	s.matrix.values[i] = f(i, i, s.matrix.values[i])
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *DiagonalMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+)
+
+// DiagonalView a read only vector view of a diagonal of a matrix, elements are read from the matrix without copying,
+// k is zero for the main diagonal, positive above and negative below
+//  view(i) = matrix(i - min(k, 0), i + max(k, 0))
+type DiagonalView struct {
+	matrix Matrix
+	r0     int // row of the first element of the diagonal
+	c0     int // column of the first element of the diagonal
+	l      int // length of the diagonal
+}
+
+// NewDiagonalView returns a DiagonalView of the k-th diagonal of the matrix
+func NewDiagonalView(m Matrix, k int) *DiagonalView {
+	r0, c0 := 0, k
+	if k < 0 {
+		r0, c0 = -k, 0
+	}
+
+	l := m.Rows() - r0
+	if m.Columns()-c0 < l {
+		l = m.Columns() - c0
+	}
+
+	if l < 0 {
+		log.Panicf("Diagonal '%+v' is invalid", k)
+	}
+
+	return &DiagonalView{matrix: m, r0: r0, c0: c0, l: l}
+}
+
+// Length of the vector
+func (s *DiagonalView) Length() int {
+	return s.l
+}
+
+// AtVec returns the value of a vector element at i-th
+func (s *DiagonalView) AtVec(i int) float64 {
+	if i < 0 || i >= s.l {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+	return s.matrix.At(s.r0+i, s.c0+i)
+}
+
+// SetVec a view is read only and panics
+func (s *DiagonalView) SetVec(i int, value float64) {
+	readOnly(i, 0)
+}
+
+// HasVec the element at i-th is stored
+func (s *DiagonalView) HasVec(i int) bool {
+	if i < 0 || i >= s.l {
+		return false
+	}
+	return s.matrix.Has(s.r0+i, s.c0+i)
+}
+
+// RemoveVec a view is read only and panics
+func (s *DiagonalView) RemoveVec(i int) {
+	readOnly(i, 0)
+}
+
+// wrapped the viewed matrix
+func (s *DiagonalView) wrapped() Matrix {
+	return s.matrix
+}
+
+// Columns the number of columns of the vector
+func (s *DiagonalView) Columns() int {
+	return 1
+}
+
+// Rows the number of rows of the vector
+func (s *DiagonalView) Rows() int {
+	return s.l
+}
+
+// Update a view is read only and panics
+func (s *DiagonalView) Update(r, c int, f func(float64) float64) {
+	readOnly(r, c)
+}
+
+// At returns the value of a vector element at r-th, c-th
+func (s *DiagonalView) At(r, c int) float64 {
+	if c != 0 {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+	return s.AtVec(r)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *DiagonalView) Has(r, c int) bool {
+	return c == 0 && s.HasVec(r)
+}
+
+// Remove a view is read only and panics
+func (s *DiagonalView) Remove(r, c int) {
+	readOnly(r, c)
+}
+
+// Set a view is read only and panics
+func (s *DiagonalView) Set(r, c int, value float64) {
+	readOnly(r, c)
+}
+
+// ColumnsAt return the columns at c-th
+func (s *DiagonalView) ColumnsAt(c int) Vector {
+	if c != 0 {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+	return s.Copy().(Vector)
+}
+
+// RowsAt return the rows at r-th
+func (s *DiagonalView) RowsAt(r int) Vector {
+	rows := NewSparseVector(1)
+	if s.HasVec(r) {
+		rows.SetVec(0, s.AtVec(r))
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *DiagonalView) RowsAtToArray(r int) []float64 {
+	return []float64{s.AtVec(r)}
+}
+
+// Copy copies the elements of the view into a new vector,
+// a DenseVector when the viewed matrix is dense otherwise a SparseVector
+func (s *DiagonalView) Copy() Matrix {
+	var vector Vector
+	if IsSparseMatrix(s.matrix) {
+		vector = NewSparseVector(s.l)
+	} else {
+		vector = NewDenseVector(s.l)
+	}
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		i, _, value := iterator.Next()
+		vector.SetVec(i, value)
+	}
+	return vector
+}
+
+// Scalar multiplication of a vector by alpha
+func (s *DiagonalView) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a vector by another matrix
+func (s *DiagonalView) Multiply(m Matrix) Matrix {
+	return s.Copy().Multiply(m)
+}
+
+// Add addition of a vector by another vector
+func (s *DiagonalView) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one vector from another vector
+func (s *DiagonalView) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a vector
+func (s *DiagonalView) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *DiagonalView) Transpose() Matrix {
+	return s.Copy().Transpose()
+}
+
+// Equal the two vectors are equal
+func (s *DiagonalView) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two vectors are not equal
+func (s *DiagonalView) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the vector
+func (s *DiagonalView) Size() int {
+	return s.l
+}
+
+// Values the number of stored elements on the diagonal
+func (s *DiagonalView) Values() int {
+	return s.NVals()
+}
+
+// NVals the number of stored elements on the diagonal
+func (s *DiagonalView) NVals() int {
+	count := 0
+	for i := 0; i < s.l; i++ {
+		if s.HasVec(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// Clear a view is read only and panics
+func (s *DiagonalView) Clear() {
+	readOnly(0, 0)
+}
+
+// Enumerate iterates through all stored elements on the diagonal
+func (s *DiagonalView) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+func (s *DiagonalView) iterator() *viewIterator {
+	i := 0
+	return newViewIterator(func() (int, int, float64, bool) {
+		for ; i < s.l; i++ {
+			if s.HasVec(i) {
+				i++
+				return i - 1, 0, s.AtVec(i - 1), true
+			}
+		}
+		return 0, 0, 0, false
+	})
+}
+
+// Map a view is read only, mapping any element panics
+func (s *DiagonalView) Map() Map {
+	return s.iterator()
+}
+
+// Element of the mask for each tuple that exists in the vector for which the value of the tuple cast to Boolean is true
+func (s *DiagonalView) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
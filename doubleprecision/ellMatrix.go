@@ -0,0 +1,203 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"reflect"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*ELLMatrix)(nil)).Elem())
+}
+
+// ELLMatrix ELLPACK storage (ELL), every row is padded to the longest row and stored column by column
+type ELLMatrix struct {
+	ell *slicedEll
+}
+
+// NewELLMatrix returns a ELLMatrix
+func NewELLMatrix(r, c int) *ELLMatrix {
+	return &ELLMatrix{ell: newSlicedEll(r, c, chunkAll(r), identityOrder(r))}
+}
+
+// NewELLMatrixFromArray returns a ELLMatrix
+func NewELLMatrixFromArray(data [][]float64) *ELLMatrix {
+	r := len(data)
+	c := len(data[0])
+	s := NewELLMatrix(r, c)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+// NewELLMatrixFromMatrix returns a ELLMatrix holding the elements of the matrix
+func NewELLMatrixFromMatrix(m Matrix) *ELLMatrix {
+	return &ELLMatrix{ell: newSlicedEllFromMatrix(m, chunkAll(m.Rows()), identityOrder(m.Rows()))}
+}
+
+// chunkAll a single slice holding every row
+func chunkAll(r int) int {
+	if r < 1 {
+		return 1
+	}
+	return r
+}
+
+func identityOrder(r int) []int {
+	order := make([]int, r)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// Columns the number of columns of the matrix
+func (s *ELLMatrix) Columns() int {
+	return s.ell.c
+}
+
+// Rows the number of rows of the matrix
+func (s *ELLMatrix) Rows() int {
+	return s.ell.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *ELLMatrix) Update(r, c int, f func(float64) float64) {
+	s.ell.update(r, c, f)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *ELLMatrix) At(r, c int) float64 {
+	return s.ell.at(r, c)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *ELLMatrix) Has(r, c int) bool {
+	return s.ell.has(r, c)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *ELLMatrix) Remove(r, c int) {
+	s.ell.delete(r, c)
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *ELLMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *ELLMatrix) ColumnsAt(c int) Vector {
+	return s.ell.columnsAt(c)
+}
+
+// RowsAt return the rows at r-th
+func (s *ELLMatrix) RowsAt(r int) Vector {
+	return s.ell.rowsAt(r)
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *ELLMatrix) RowsAtToArray(r int) []float64 {
+	return s.ell.rowsAtToArray(r)
+}
+
+// Copy copies the matrix
+func (s *ELLMatrix) Copy() Matrix {
+	return &ELLMatrix{ell: s.ell.copy()}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *ELLMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *ELLMatrix) Multiply(m Matrix) Matrix {
+	matrix := NewELLMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *ELLMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *ELLMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *ELLMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *ELLMatrix) Transpose() Matrix {
+	matrix := NewCSRMatrix(s.Columns(), s.Rows())
+	Transpose(context.Background(), s, nil, matrix)
+	return NewELLMatrixFromMatrix(matrix)
+}
+
+// Equal the two matrices are equal
+func (s *ELLMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *ELLMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *ELLMatrix) Size() int {
+	return s.Rows() * s.Columns()
+}
+
+// Values the number of stored elements in the matrix
+func (s *ELLMatrix) Values() int {
+	return s.ell.count
+}
+
+// NVals the number of stored elements in the matrix
+func (s *ELLMatrix) NVals() int {
+	return s.ell.count
+}
+
+// Clear removes all elements from a matrix
+func (s *ELLMatrix) Clear() {
+	s.ell.clear()
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *ELLMatrix) Enumerate() Enumerate {
+	return s.ell.iterator()
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *ELLMatrix) Map() Map {
+	return s.ell.iterator()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *ELLMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
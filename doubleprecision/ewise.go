@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+// binaryApply the function of a BinaryOpFloat64 or BinaryOpFloat64ToBool, true is one and false is zero
+func binaryApply(op binaryop.BinaryOp) func(float64, float64) float64 {
+	switch op := op.(type) {
+	case float64op.BinaryOpFloat64:
+		return op.Apply
+	case float64op.BinaryOpFloat64ToBool:
+		return func(in1, in2 float64) float64 {
+			if op.Apply(in1, in2) {
+				return 1
+			}
+			return 0
+		}
+	}
+
+	log.Panicf("Can not apply the binary operator %T", op)
+	return nil
+}
+
+func checkElementWise(s, m Matrix) {
+	if s.Columns() != m.Columns() {
+		log.Panicf("Column mismatch %+v, %+v", s.Columns(), m.Columns())
+	}
+
+	if s.Rows() != m.Rows() {
+		log.Panicf("Row mismatch %+v, %+v", s.Rows(), m.Rows())
+	}
+}
+
+// EWiseMult element-wise multiplication over the intersection of the elements stored in both matrices,
+// the operator is a BinaryOpFloat64 or BinaryOpFloat64ToBool
+//  C<M> ⊙= A .* B
+func EWiseMult(ctx context.Context, s, m Matrix, op binaryop.BinaryOp, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	checkElementWise(s, m)
+	apply := binaryApply(op)
+
+	t := newCOOMatrix(s.Rows(), s.Columns(), 0)
+
+	// enumerate the matrix with the fewest elements keeping the order of the operands
+	if s.NVals() <= m.NVals() {
+		for iterator := s.Enumerate(); iterator.HasNext(); {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				r, c, value := iterator.Next()
+				if m.Has(r, c) {
+					t.Set(r, c, apply(value, m.At(r, c)))
+				}
+			}
+		}
+	} else {
+		for iterator := m.Enumerate(); iterator.HasNext(); {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				r, c, value := iterator.Next()
+				if s.Has(r, c) {
+					t.Set(r, c, apply(s.At(r, c), value))
+				}
+			}
+		}
+	}
+
+	accumulate(ctx, t, mask, accumulator, matrix)
+}
+
+// EWiseAdd element-wise addition over the union of the elements stored in either matrix,
+// the operator is applied where both are stored otherwise the stored value is kept
+//  C<M> ⊙= A .+ B
+func EWiseAdd(ctx context.Context, s, m Matrix, op binaryop.BinaryOp, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	checkElementWise(s, m)
+	apply := binaryApply(op)
+
+	union(ctx, s, m, func(value float64) float64 {
+		return value
+	}, func(a, b float64) float64 {
+		return apply(a, b)
+	}, func(value float64) float64 {
+		return value
+	}, mask, accumulator, matrix)
+}
+
+// EWiseUnion element-wise addition over the union of the elements stored in either matrix,
+// where only one is stored alpha fills in for A and beta for B so the operator is always applied
+//  C<M> ⊙= (A ∪ alpha) .+ (B ∪ beta)
+func EWiseUnion(ctx context.Context, s Matrix, alpha float64, m Matrix, beta float64, op binaryop.BinaryOp, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	checkElementWise(s, m)
+	apply := binaryApply(op)
+
+	union(ctx, s, m, func(value float64) float64 {
+		return apply(value, beta)
+	}, func(a, b float64) float64 {
+		return apply(a, b)
+	}, func(value float64) float64 {
+		return apply(alpha, value)
+	}, mask, accumulator, matrix)
+}
+
+// union of the elements of s and m, onlyS for elements only in s, both for elements in both and onlyM for elements only in m
+func union(ctx context.Context, s, m Matrix, onlyS func(float64) float64, both func(float64, float64) float64, onlyM func(float64) float64, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	t := newCOOMatrix(s.Rows(), s.Columns(), 0)
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, value := iterator.Next()
+			if m.Has(r, c) {
+				t.Set(r, c, both(value, m.At(r, c)))
+			} else {
+				t.Set(r, c, onlyS(value))
+			}
+		}
+	}
+
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, value := iterator.Next()
+			if !s.Has(r, c) {
+				t.Set(r, c, onlyM(value))
+			}
+		}
+	}
+
+	accumulate(ctx, t, mask, accumulator, matrix)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+// Extract the elements at the rows and columns of a matrix into a matrix of len(rows)×len(cols),
+// use All, a List, Range or Stride for the indices
+//  C<M> ⊙= A(rows, cols)
+func Extract(ctx context.Context, s Matrix, rows, cols Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	extract(ctx, s, rows.indices(s.Rows()), cols.indices(s.Columns()), mask, accumulator, matrix)
+}
+
+func extract(ctx context.Context, s Matrix, rows, cols []int, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	if matrix.Rows() != len(rows) || matrix.Columns() != len(cols) {
+		log.Panicf("Can not extract %+v×%+v into %+v×%+v", len(rows), len(cols), matrix.Rows(), matrix.Columns())
+	}
+
+	// the output columns of each column of s
+	positions := make(map[int][]int, len(cols))
+	for j, c := range cols {
+		positions[c] = append(positions[c], j)
+	}
+
+	t := newCOOMatrix(len(rows), len(cols), 0)
+	for i, r := range rows {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			for iterator := s.RowsAt(r).Enumerate(); iterator.HasNext(); {
+				c, _, value := iterator.Next()
+				for _, j := range positions[c] {
+					t.Set(i, j, value)
+				}
+			}
+		}
+	}
+
+	accumulate(ctx, t, mask, accumulator, matrix)
+}
+
+// ExtractVector the elements at the indices of a vector into a vector of len(indices)
+//  w<m> ⊙= u(indices)
+func ExtractVector(ctx context.Context, s Vector, indices Index, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	Extract(ctx, s, indices, All, mask, accumulator, vector)
+}
+
+// ExtractColumn the elements at the rows of the c-th column of a matrix into a vector of len(rows)
+//  w<m> ⊙= A(rows, c)
+func ExtractColumn(ctx context.Context, s Matrix, rows Index, c int, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	Extract(ctx, s, rows, List{c}, mask, accumulator, vector)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import "log"
+
+// Index a selection of rows or columns, use All, a List, Range or Stride
+type Index interface {
+	// indices the selected indices out of n
+	indices(n int) []int
+}
+
+// all the type of All
+type all int
+
+// All selects every row or column in order
+const All all = 0
+
+func (s all) indices(n int) []int {
+	return Range(0, n)
+}
+
+// List selects the rows or columns at each index in order, indices can repeat and an empty list selects none
+type List []int
+
+func (s List) indices(n int) []int {
+	for _, i := range s {
+		if i < 0 || i >= n {
+			log.Panicf("Index '%+v' is invalid", i)
+		}
+	}
+	return s
+}
+
+// Range the indices from begin up to but not including end
+func Range(begin, end int) List {
+	return Stride(begin, end, 1)
+}
+
+// Stride the indices from begin up to but not including end in steps of stride,
+// a negative stride counts down from begin to end
+func Stride(begin, end, stride int) List {
+	if stride == 0 {
+		log.Panicf("Stride '%+v' is invalid", stride)
+	}
+
+	indices := List{}
+	if stride > 0 {
+		for i := begin; i < end; i += stride {
+			indices = append(indices, i)
+		}
+	} else {
+		for i := begin; i > end; i += stride {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
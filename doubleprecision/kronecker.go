@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+)
+
+// Kronecker product of two matrices using the operator in place of multiplication,
+// only elements stored in both a and b are in the output which is of size a.Rows()*b.Rows() × a.Columns()*b.Columns()
+//  C<M> = kron(A, B)
+//  C(i * B.Rows() + k, j * B.Columns() + l) = f(A(i, j), B(k, l))
+func Kronecker(ctx context.Context, a, b Matrix, op float64op.BinaryOpFloat64, mask GraphBLAS.Mask, matrix Matrix) {
+	r := a.Rows() * b.Rows()
+	c := a.Columns() * b.Columns()
+
+	if matrix.Rows() != r || matrix.Columns() != c {
+		log.Panicf("Can not make a kronecker product of %+v×%+v in %+v×%+v", r, c, matrix.Rows(), matrix.Columns())
+	}
+
+	bRows, bCols, bValues := []int{}, []int{}, []float64{}
+	for iterator := b.Enumerate(); iterator.HasNext(); {
+		k, l, value := iterator.Next()
+		bRows = append(bRows, k)
+		bCols = append(bCols, l)
+		bValues = append(bValues, value)
+	}
+
+	nvals := a.NVals() * len(bValues)
+	rows, cols, values := make([]int, 0, nvals), make([]int, 0, nvals), make([]float64, 0, nvals)
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			i, j, value := iterator.Next()
+			for p := range bValues {
+				rows = append(rows, i*b.Rows()+bRows[p])
+				cols = append(cols, j*b.Columns()+bCols[p])
+				values = append(values, op.Apply(value, bValues[p]))
+			}
+		}
+	}
+
+	t := newCOOMatrix(r, c, 0)
+	t.Build(rows, cols, values, nil)
+
+	// without a mask the product replaces the output so a CSR matrix can take the compressed rows as is
+	if csr, ok := matrix.(*CSRMatrix); ok && mask == nil {
+		*csr = *t.ToCSRMatrix()
+		return
+	}
+
+	accumulate(ctx, t, mask, nil, matrix)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package cholesky Cholesky factorisation of symmetric positive definite matrices
+package cholesky
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/symmetric"
+)
+
+// ErrNotSymmetric the matrix is not symmetric
+var ErrNotSymmetric = errors.New("cholesky: matrix is not symmetric")
+
+// ErrNotPositiveDefinite the matrix is not positive definite
+var ErrNotPositiveDefinite = errors.New("cholesky: matrix is not positive definite")
+
+// Cholesky factorisation of a dense symmetric positive definite matrix
+//  A = LLᵀ
+type Cholesky struct {
+	n int
+	l [][]float64
+}
+
+// Decompose factorises the matrix
+func Decompose(ctx context.Context, s doubleprecision.Matrix) (*Cholesky, error) {
+	if !symmetric.Symmetric(s) {
+		return nil, ErrNotSymmetric
+	}
+
+	n := s.Rows()
+	f := &Cholesky{n: n, l: make([][]float64, n)}
+
+	for j := 0; j < n; j++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		row := s.RowsAtToArray(j)
+		f.l[j] = make([]float64, j+1)
+
+		for k := 0; k < j; k++ {
+			sum := row[k]
+			for i := 0; i < k; i++ {
+				sum -= f.l[k][i] * f.l[j][i]
+			}
+			f.l[j][k] = sum / f.l[k][k]
+		}
+
+		d := row[j]
+		for k := 0; k < j; k++ {
+			d -= f.l[j][k] * f.l[j][k]
+		}
+		if d <= 0 {
+			return nil, ErrNotPositiveDefinite
+		}
+		f.l[j][j] = math.Sqrt(d)
+	}
+
+	return f, nil
+}
+
+// L the lower triangular factor
+func (s *Cholesky) L() doubleprecision.Matrix {
+	l := doubleprecision.NewDenseMatrix(s.n, s.n)
+	for r, row := range s.l {
+		for c, v := range row {
+			l.Set(r, c, v)
+		}
+	}
+	return l
+}
+
+// Determinant the square of the product of the diagonal of L
+func (s *Cholesky) Determinant() float64 {
+	d := 1.0
+	for k := 0; k < s.n; k++ {
+		d *= s.l[k][k]
+	}
+	return d * d
+}
+
+// Solve solves Ax = b
+func (s *Cholesky) Solve(b doubleprecision.Vector) doubleprecision.Vector {
+	if b.Length() != s.n {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.n, b.Length())
+	}
+
+	x := make([]float64, s.n)
+	for i := 0; i < s.n; i++ {
+		x[i] = b.AtVec(i)
+		for k := 0; k < i; k++ {
+			x[i] -= s.l[i][k] * x[k]
+		}
+		x[i] /= s.l[i][i]
+	}
+
+	for i := s.n - 1; i >= 0; i-- {
+		x[i] /= s.l[i][i]
+		for k := 0; k < i; k++ {
+			x[k] -= s.l[i][k] * x[i]
+		}
+	}
+
+	return doubleprecision.NewDenseVectorFromArray(x)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cholesky_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/cholesky"
+)
+
+var array = [][]float64{
+	[]float64{4, 12, -16, 0},
+	[]float64{12, 37, -43, 0},
+	[]float64{-16, -43, 98, 1},
+	[]float64{0, 0, 1, 2},
+}
+
+func equal(t *testing.T, name string, got, want doubleprecision.Matrix) {
+	t.Helper()
+	for r := 0; r < want.Rows(); r++ {
+		for c := 0; c < want.Columns(); c++ {
+			if math.Abs(got.At(r, c)-want.At(r, c)) > 1e-9 {
+				t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", name, r, c, got.At(r, c), want.At(r, c))
+			}
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := cholesky.Decompose(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			l := f.L()
+			equal(t, tt.name, l.Multiply(l.Transpose()), tt.s)
+
+			x := doubleprecision.NewDenseVectorFromArray([]float64{1, 2, 3, 4})
+			b := doubleprecision.NewDenseVector(4)
+			doubleprecision.MatrixVectorMultiply(context.Background(), tt.s, x, nil, b)
+			equal(t, tt.name, f.Solve(b), x)
+		})
+	}
+}
+
+func TestDecomposeSparse(t *testing.T) {
+	a := doubleprecision.NewCSCMatrixFromArray(array)
+	f, err := cholesky.DecomposeSparse(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dense, err := cholesky.Decompose(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "SparseCholesky", f.L(), dense.L())
+
+	x := doubleprecision.NewDenseVectorFromArray([]float64{1, 2, 3, 4})
+	b := doubleprecision.NewDenseVector(4)
+	doubleprecision.MatrixVectorMultiply(context.Background(), a, x, nil, b)
+	equal(t, "SparseCholesky", f.Solve(b), x)
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		array [][]float64
+		want  error
+	}{
+		{
+			name: "NotSymmetric",
+			array: [][]float64{
+				[]float64{4, 1},
+				[]float64{2, 4},
+			},
+			want: cholesky.ErrNotSymmetric,
+		},
+		{
+			name: "NotPositiveDefinite",
+			array: [][]float64{
+				[]float64{1, 2},
+				[]float64{2, 1},
+			},
+			want: cholesky.ErrNotPositiveDefinite,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cholesky.Decompose(context.Background(), doubleprecision.NewDenseMatrixFromArray(tt.array)); err != tt.want {
+				t.Errorf("%+v Decompose error = %+v, want %+v", tt.name, err, tt.want)
+			}
+			if _, err := cholesky.DecomposeSparse(context.Background(), doubleprecision.NewCSCMatrixFromArray(tt.array)); err != tt.want {
+				t.Errorf("%+v DecomposeSparse error = %+v, want %+v", tt.name, err, tt.want)
+			}
+		})
+	}
+}
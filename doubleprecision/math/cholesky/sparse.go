@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cholesky
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/symmetric"
+)
+
+// column of the sparse factor, rows are sorted with the diagonal first
+type column struct {
+	rows   []int
+	values []float64
+}
+
+// SparseCholesky left-looking column Cholesky factorisation of a sparse symmetric positive definite matrix
+//  A = LLᵀ
+type SparseCholesky struct {
+	n int
+	l []column
+}
+
+// DecomposeSparse factorises the matrix, only the lower triangle is read after the symmetry check
+func DecomposeSparse(ctx context.Context, s *doubleprecision.CSCMatrix) (*SparseCholesky, error) {
+	if !symmetric.Symmetric(s) {
+		return nil, ErrNotSymmetric
+	}
+
+	n := s.Rows()
+	f := &SparseCholesky{n: n, l: make([]column, n)}
+
+	// the columns k < j with a non-zero in row j of L
+	rowPattern := make([][]int, n)
+	x := make([]float64, n)
+	marked := make([]bool, n)
+
+	for j := 0; j < n; j++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		pattern := []int{}
+		for iterator := s.ColumnsAt(j).Enumerate(); iterator.HasNext(); {
+			r, _, value := iterator.Next()
+			if r < j {
+				continue
+			}
+			x[r] = value
+			marked[r] = true
+			pattern = append(pattern, r)
+		}
+
+		// x = A(j:n, j) - L(j:n, k)L(j, k) for every k left of j
+		for _, k := range rowPattern[j] {
+			col := f.l[k]
+			p := sort.SearchInts(col.rows, j)
+			ljk := col.values[p]
+			for i := p; i < len(col.rows); i++ {
+				r := col.rows[i]
+				if !marked[r] {
+					marked[r] = true
+					x[r] = 0
+					pattern = append(pattern, r)
+				}
+				x[r] -= col.values[i] * ljk
+			}
+		}
+
+		sort.Ints(pattern)
+		if len(pattern) == 0 || pattern[0] != j || x[j] <= 0 {
+			return nil, ErrNotPositiveDefinite
+		}
+
+		d := math.Sqrt(x[j])
+		col := column{
+			rows:   make([]int, 0, len(pattern)),
+			values: make([]float64, 0, len(pattern)),
+		}
+		for _, r := range pattern {
+			value := x[r] / d
+			if r == j {
+				value = d
+			}
+			if value != 0 {
+				col.rows = append(col.rows, r)
+				col.values = append(col.values, value)
+				if r != j {
+					rowPattern[r] = append(rowPattern[r], j)
+				}
+			}
+			marked[r] = false
+			x[r] = 0
+		}
+		f.l[j] = col
+	}
+
+	return f, nil
+}
+
+// L the lower triangular factor
+func (s *SparseCholesky) L() doubleprecision.Matrix {
+	l := doubleprecision.NewCSCMatrix(s.n, s.n)
+	for c, col := range s.l {
+		for i, r := range col.rows {
+			l.Set(r, c, col.values[i])
+		}
+	}
+	return l
+}
+
+// Solve solves Ax = b
+func (s *SparseCholesky) Solve(b doubleprecision.Vector) doubleprecision.Vector {
+	if b.Length() != s.n {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.n, b.Length())
+	}
+
+	x := make([]float64, s.n)
+	for i := range x {
+		x[i] = b.AtVec(i)
+	}
+
+	// Ly = b, column oriented
+	for j, col := range s.l {
+		x[j] /= col.values[0]
+		for i := 1; i < len(col.rows); i++ {
+			x[col.rows[i]] -= col.values[i] * x[j]
+		}
+	}
+
+	// Lᵀx = y, a dot product with each column
+	for j := s.n - 1; j >= 0; j-- {
+		col := s.l[j]
+		for i := 1; i < len(col.rows); i++ {
+			x[j] -= col.values[i] * x[col.rows[i]]
+		}
+		x[j] /= col.values[0]
+	}
+
+	return doubleprecision.NewDenseVectorFromArray(x)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package eigen eigenvalues and eigenvectors of dense and sparse matrices
+package eigen
+
+import (
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// ErrNotConverged the iteration limit was reached before the tolerance
+var ErrNotConverged = errors.New("eigen: did not converge")
+
+// ErrNotSymmetric the matrix is not symmetric
+var ErrNotSymmetric = errors.New("eigen: matrix is not symmetric")
+
+// epsilon machine precision for float64
+var epsilon = math.Nextafter(1, 2) - 1
+
+// Eigenpair a eigenvalue and its unit length eigenvector
+//  Av = λv
+type Eigenpair struct {
+	Value  float64
+	Vector doubleprecision.Vector
+}
+
+// Which end of the spectrum to find
+type Which int
+
+const (
+	// Largest the algebraically largest eigenvalues
+	Largest Which = iota
+	// Smallest the algebraically smallest eigenvalues
+	Smallest
+)
+
+// newOperator row-wise copy of a square matrix for repeated multiplication
+func newOperator(a doubleprecision.Matrix) *operator.Operator {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not find the eigenvalues of a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	return operator.New(a)
+}
+
+func scale(alpha float64, x []float64) {
+	for i := range x {
+		x[i] *= alpha
+	}
+}
+
+// residual ‖Ax - λx‖
+func residual(op *operator.Operator, value float64, x, work []float64) float64 {
+	op.Multiply(x, work)
+	for i := range work {
+		work[i] -= value * x[i]
+	}
+	return operator.Norm(work)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package eigen_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/eigen"
+)
+
+// laplacian of the path graph with n vertices, its eigenvalues are 2 - 2cos(kπ/n)
+func laplacian(n int) *doubleprecision.CSRMatrix {
+	a := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		degree := 0.0
+		if i > 0 {
+			a.Set(i, i-1, -1)
+			degree++
+		}
+		if i < n-1 {
+			a.Set(i, i+1, -1)
+			degree++
+		}
+		a.Set(i, i, degree)
+	}
+	return a
+}
+
+func pathEigenvalue(n, k int) float64 {
+	return 2 - 2*math.Cos(float64(k)*math.Pi/float64(n))
+}
+
+// check the pair satisfies Av = λv with a unit length v
+func check(t *testing.T, name string, a doubleprecision.Matrix, pair eigen.Eigenpair, want float64) {
+	t.Helper()
+	if math.Abs(pair.Value-want) > 1e-6 {
+		t.Errorf("%+v Value = %+v, want %+v", name, pair.Value, want)
+	}
+
+	av := doubleprecision.NewDenseVector(a.Rows())
+	doubleprecision.MatrixVectorMultiply(context.Background(), a, pair.Vector, nil, av)
+	length := 0.0
+	for i := 0; i < a.Rows(); i++ {
+		length += pair.Vector.AtVec(i) * pair.Vector.AtVec(i)
+		if math.Abs(av.AtVec(i)-pair.Value*pair.Vector.AtVec(i)) > 1e-5 {
+			t.Errorf("%+v Av(%+v) = %+v, want %+v", name, i, av.AtVec(i), pair.Value*pair.Vector.AtVec(i))
+		}
+	}
+	if math.Abs(length-1) > 1e-9 {
+		t.Errorf("%+v vector length = %+v, want %+v", name, length, 1)
+	}
+}
+
+func TestPower(t *testing.T) {
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{2, 1},
+		[]float64{1, 3},
+	})
+
+	got, err := eigen.Power(context.Background(), a, 1e-10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, "Power", a, *got, (5+math.Sqrt(5))/2)
+}
+
+func TestInverse(t *testing.T) {
+	a := laplacian(10)
+
+	got, err := eigen.Inverse(context.Background(), a, 0.1, 1e-10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, "Inverse", a, *got, pathEigenvalue(10, 1))
+
+	// a shift on an eigenvalue is nudged so the system can still be solved
+	got, err = eigen.Inverse(context.Background(), a, 0, 1e-10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, "Inverse", a, *got, 0)
+}
+
+func TestSymmetric(t *testing.T) {
+	a := laplacian(6)
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(toArray(a)),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(toArray(a)),
+		},
+		{
+			name: "CSRMatrix",
+			s:    a,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := eigen.Symmetric(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, pair := range pairs {
+				check(t, tt.name, tt.s, pair, pathEigenvalue(6, k))
+			}
+		})
+	}
+}
+
+func TestSymmetric_NotSymmetric(t *testing.T) {
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 2},
+		[]float64{3, 4},
+	})
+	if _, err := eigen.Symmetric(context.Background(), a); err != eigen.ErrNotSymmetric {
+		t.Errorf("Symmetric error = %+v, want %+v", err, eigen.ErrNotSymmetric)
+	}
+}
+
+func TestTridiagonal(t *testing.T) {
+	pairs, err := eigen.Tridiagonal(context.Background(), []float64{2, 2, 2}, []float64{-1, -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// eigenvalues of the 3×3 second difference matrix are 2 - 2cos(kπ/4)
+	for k, pair := range pairs {
+		want := 2 - 2*math.Cos(float64(k+1)*math.Pi/4)
+		if math.Abs(pair.Value-want) > 1e-9 {
+			t.Errorf("Tridiagonal Value = %+v, want %+v", pair.Value, want)
+		}
+	}
+}
+
+func TestLanczos(t *testing.T) {
+	n := 40
+	a := laplacian(n)
+
+	largest, err := eigen.Lanczos(context.Background(), a, 3, eigen.Largest, 1e-8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pair := range largest {
+		check(t, "Largest", a, pair, pathEigenvalue(n, n-1-i))
+	}
+
+	smallest, err := eigen.Lanczos(context.Background(), a, 2, eigen.Smallest, 1e-8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pair := range smallest {
+		check(t, "Smallest", a, pair, pathEigenvalue(n, i))
+	}
+}
+
+func TestLanczos_NotSymmetric(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		[]float64{1, 2},
+		[]float64{3, 4},
+	})
+	if _, err := eigen.Lanczos(context.Background(), a, 1, eigen.Largest, 1e-8); err != eigen.ErrNotSymmetric {
+		t.Errorf("Lanczos error = %+v, want %+v", err, eigen.ErrNotSymmetric)
+	}
+}
+
+func toArray(s doubleprecision.Matrix) [][]float64 {
+	array := make([][]float64, s.Rows())
+	for r := range array {
+		array[r] = s.RowsAtToArray(r)
+	}
+	return array
+}
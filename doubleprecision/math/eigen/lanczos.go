@@ -0,0 +1,163 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package eigen
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+	"github.com/rossmerr/graphblas/doubleprecision/math/symmetric"
+)
+
+// Lanczos the k largest or smallest eigenpairs of a sparse symmetric matrix
+// the Krylov basis is fully reorthogonalised and grown until every wanted Ritz pair has a residual ‖Av - λv‖ ≤ tolerance·max(|λ|, 1)
+// the pairs are ordered from the requested end of the spectrum inwards, returns ErrNotSymmetric when the matrix is not symmetric
+func Lanczos(ctx context.Context, a *doubleprecision.CSRMatrix, k int, which Which, tolerance float64) ([]Eigenpair, error) {
+	if !symmetric.Symmetric(a) {
+		return nil, ErrNotSymmetric
+	}
+
+	op := newOperator(a)
+	n := op.N
+	if k < 1 || k > n {
+		log.Panicf("Can not find %+v eigenpairs of a %+v×%+v matrix", k, n, n)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	basis := [][]float64{}
+	alpha := []float64{}
+	beta := []float64{}
+
+	start := func() []float64 {
+		for {
+			v := make([]float64, n)
+			for i := range v {
+				v[i] = random.Float64() - 0.5
+			}
+			orthogonalise(basis, v)
+			if nv := operator.Norm(v); nv > epsilon {
+				scale(1/nv, v)
+				return v
+			}
+		}
+	}
+
+	v := start()
+	w := make([]float64, n)
+
+	for j := 0; j < n; j++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		basis = append(basis, v)
+		op.Multiply(v, w)
+		alpha = append(alpha, operator.Dot(w, v))
+		orthogonalise(basis, w)
+
+		b := operator.Norm(w)
+		m := len(basis)
+
+		if m >= k {
+			values, vectors, err := ritz(ctx, alpha, beta)
+			if err != nil {
+				return nil, err
+			}
+
+			wanted := selection(values, k, which)
+			converged := true
+			for _, i := range wanted {
+				// the residual of a Ritz pair is β times the last component of its eigenvector of T
+				if math.Abs(b*vectors[m-1][i]) > tolerance*math.Max(math.Abs(values[i]), 1) {
+					converged = false
+					break
+				}
+			}
+
+			if converged || m == n {
+				return combine(basis, values, vectors, wanted), nil
+			}
+		}
+
+		if b <= epsilon*math.Max(math.Abs(alpha[j]), 1) {
+			// an invariant subspace was found, continue from a new vector orthogonal to it
+			beta = append(beta, 0)
+			v = start()
+			continue
+		}
+
+		beta = append(beta, b)
+		v = make([]float64, n)
+		for i := range w {
+			v[i] = w[i] / b
+		}
+	}
+
+	return nil, ErrNotConverged
+}
+
+// orthogonalise removes the components of the basis from w, twice is enough
+func orthogonalise(basis [][]float64, w []float64) {
+	for pass := 0; pass < 2; pass++ {
+		for _, q := range basis {
+			h := operator.Dot(w, q)
+			for i := range w {
+				w[i] -= h * q[i]
+			}
+		}
+	}
+}
+
+// ritz the eigenpairs of the tridiagonal matrix T built from alpha and beta
+func ritz(ctx context.Context, alpha, beta []float64) ([]float64, [][]float64, error) {
+	m := len(alpha)
+	v := identity(m)
+	d := append([]float64{}, alpha...)
+	e := make([]float64, m)
+	copy(e[1:], beta[:m-1])
+
+	if err := tql2(ctx, v, d, e); err != nil {
+		return nil, nil, err
+	}
+	return d, v, nil
+}
+
+// selection the indices of the k wanted values from the requested end inwards, values are in ascending order
+func selection(values []float64, k int, which Which) []int {
+	wanted := make([]int, k)
+	for i := range wanted {
+		if which == Smallest {
+			wanted[i] = i
+		} else {
+			wanted[i] = len(values) - 1 - i
+		}
+	}
+	return wanted
+}
+
+// combine the Ritz vectors, the basis times the eigenvectors of T
+func combine(basis [][]float64, values []float64, vectors [][]float64, wanted []int) []Eigenpair {
+	n := len(basis[0])
+	result := make([]Eigenpair, len(wanted))
+	for p, i := range wanted {
+		x := make([]float64, n)
+		for j, q := range basis {
+			for r := range x {
+				x[r] += vectors[j][i] * q[r]
+			}
+		}
+		scale(1/operator.Norm(x), x)
+		result[p] = *pair(values[i], x)
+	}
+
+	return result
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package eigen
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+	"github.com/rossmerr/graphblas/doubleprecision/math/lu"
+)
+
+// Power iteration for the eigenvalue of largest magnitude
+// converges when ‖Av - λv‖ ≤ tolerance·|λ|, slowly if the two largest eigenvalues are close in magnitude
+func Power(ctx context.Context, a doubleprecision.Matrix, tolerance float64, iterations int) (*Eigenpair, error) {
+	op := newOperator(a)
+	x := make([]float64, op.N)
+	for i := range x {
+		x[i] = 1 / math.Sqrt(float64(op.N))
+	}
+	y := make([]float64, op.N)
+	work := make([]float64, op.N)
+
+	value := 0.0
+	for i := 0; i < iterations; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		op.Multiply(x, y)
+		value = operator.Dot(x, y)
+		n := operator.Norm(y)
+		if n == 0 {
+			// x lies in the null space so it is an eigenvector of 0
+			return pair(0, x), nil
+		}
+		scale(1/n, y)
+		x, y = y, x
+
+		if residual(op, value, x, work) <= tolerance*math.Abs(value) {
+			// the Rayleigh quotient of the new x, xᵀAx = xᵀ(Ax - λx) + λ
+			value = operator.Dot(x, work) + value
+			return pair(value, x), nil
+		}
+	}
+
+	return pair(value, x), ErrNotConverged
+}
+
+// Inverse iteration for the eigenvalue closest to the shift σ, (A - σI) is factorised once with LU
+func Inverse(ctx context.Context, a doubleprecision.Matrix, shift, tolerance float64, iterations int) (*Eigenpair, error) {
+	op := newOperator(a)
+
+	shifted := func(sigma float64) (*lu.LU, error) {
+		m := doubleprecision.NewDenseMatrix(op.N, op.N)
+		for r := 0; r < op.N; r++ {
+			for i, c := range op.Cols[r] {
+				m.Set(r, c, op.Values[r][i])
+			}
+			m.Update(r, r, func(v float64) float64 {
+				return v - sigma
+			})
+		}
+		return lu.Decompose(ctx, m)
+	}
+
+	f, err := shifted(shift)
+	if err != nil {
+		return nil, err
+	}
+	if f.Singular() {
+		// the shift is an eigenvalue, move it slightly so the system can be solved
+		f, err = shifted(shift + math.Max(math.Abs(shift), 1)*1e-10)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a random start is unlikely to be orthogonal to the wanted eigenvector
+	random := rand.New(rand.NewSource(1))
+	x := doubleprecision.NewDenseVector(op.N)
+	for i := 0; i < op.N; i++ {
+		x.SetVec(i, random.Float64()-0.5)
+	}
+	values := make([]float64, op.N)
+	work := make([]float64, op.N)
+
+	value := shift
+	for i := 0; i < iterations; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		y, err := f.Solve(x)
+		if err != nil {
+			return nil, err
+		}
+
+		for r := range values {
+			values[r] = y.AtVec(r)
+		}
+		scale(1/operator.Norm(values), values)
+		for r, v := range values {
+			x.SetVec(r, v)
+		}
+
+		op.Multiply(values, work)
+		value = operator.Dot(values, work)
+		if residual(op, value, values, work) <= tolerance*math.Max(math.Abs(value), 1) {
+			return pair(value, values), nil
+		}
+	}
+
+	return pair(value, values), ErrNotConverged
+}
+
+func pair(value float64, x []float64) *Eigenpair {
+	return &Eigenpair{Value: value, Vector: doubleprecision.NewDenseVectorFromArray(x)}
+}
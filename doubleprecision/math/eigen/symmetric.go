@@ -0,0 +1,290 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package eigen
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/symmetric"
+)
+
+// Symmetric all eigenpairs of a small dense symmetric matrix in ascending order
+// the matrix is reduced to tridiagonal form by Householder reflections then solved with the implicit QL algorithm
+func Symmetric(ctx context.Context, a doubleprecision.Matrix) ([]Eigenpair, error) {
+	if !symmetric.Symmetric(a) {
+		return nil, ErrNotSymmetric
+	}
+
+	n := a.Rows()
+	v := make([][]float64, n)
+	for r := 0; r < n; r++ {
+		v[r] = a.RowsAtToArray(r)
+	}
+	d := make([]float64, n)
+	e := make([]float64, n)
+
+	tridiagonalise(v, d, e)
+	if err := tql2(ctx, v, d, e); err != nil {
+		return nil, err
+	}
+
+	return pairs(v, d), nil
+}
+
+// Tridiagonal all eigenpairs of the symmetric tridiagonal matrix in ascending order
+// diagonal has n elements and offDiagonal the n-1 elements below (and above) the diagonal
+func Tridiagonal(ctx context.Context, diagonal, offDiagonal []float64) ([]Eigenpair, error) {
+	n := len(diagonal)
+	v := identity(n)
+	d := append([]float64{}, diagonal...)
+	e := make([]float64, n)
+	copy(e[1:], offDiagonal)
+
+	if err := tql2(ctx, v, d, e); err != nil {
+		return nil, err
+	}
+
+	return pairs(v, d), nil
+}
+
+func identity(n int) [][]float64 {
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+	return v
+}
+
+// pairs the columns of v with the values of d
+func pairs(v [][]float64, d []float64) []Eigenpair {
+	result := make([]Eigenpair, len(d))
+	for k := range d {
+		vector := doubleprecision.NewDenseVector(len(v))
+		for i := range v {
+			vector.SetVec(i, v[i][k])
+		}
+		result[k] = Eigenpair{Value: d[k], Vector: vector}
+	}
+	return result
+}
+
+// tridiagonalise symmetric Householder reduction to tridiagonal form (tred2)
+// on return d holds the diagonal, e[1:] the sub-diagonal and v the accumulated orthogonal transformation
+func tridiagonalise(v [][]float64, d, e []float64) {
+	n := len(d)
+	if n == 0 {
+		return
+	}
+
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+	}
+
+	for i := n - 1; i > 0; i-- {
+		scale := 0.0
+		h := 0.0
+		for k := 0; k < i; k++ {
+			scale += math.Abs(d[k])
+		}
+
+		if scale == 0 {
+			e[i] = d[i-1]
+			for j := 0; j < i; j++ {
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+				v[j][i] = 0
+			}
+		} else {
+			for k := 0; k < i; k++ {
+				d[k] /= scale
+				h += d[k] * d[k]
+			}
+			f := d[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+			e[i] = scale * g
+			h -= f * g
+			d[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0
+			}
+
+			for j := 0; j < i; j++ {
+				f = d[j]
+				v[j][i] = f
+				g = e[j] + v[j][j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += v[k][j] * d[k]
+					e[k] += v[k][j] * f
+				}
+				e[j] = g
+			}
+
+			f = 0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * d[j]
+			}
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * d[j]
+			}
+			for j := 0; j < i; j++ {
+				f = d[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					v[k][j] -= f*e[k] + g*d[k]
+				}
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+			}
+		}
+		d[i] = h
+	}
+
+	// accumulate the transformations
+	for i := 0; i < n-1; i++ {
+		v[n-1][i] = v[i][i]
+		v[i][i] = 1
+		h := d[i+1]
+		if h != 0 {
+			for k := 0; k <= i; k++ {
+				d[k] = v[k][i+1] / h
+			}
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += v[k][i+1] * v[k][j]
+				}
+				for k := 0; k <= i; k++ {
+					v[k][j] -= g * d[k]
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			v[k][i+1] = 0
+		}
+	}
+
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+		v[n-1][j] = 0
+	}
+	v[n-1][n-1] = 1
+	e[0] = 0
+}
+
+// tql2 implicit QL algorithm for the symmetric tridiagonal matrix with diagonal d and sub-diagonal e[1:]
+// the eigenvalues are returned in d in ascending order and the rotations are applied to the columns of v
+func tql2(ctx context.Context, v [][]float64, d, e []float64) error {
+	n := len(d)
+	if n == 0 {
+		return nil
+	}
+
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0
+
+	f := 0.0
+	tst1 := 0.0
+	for l := 0; l < n; l++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n-1 && math.Abs(e[m]) > epsilon*tst1 {
+			m++
+		}
+
+		if m > l {
+			for iteration := 0; ; iteration++ {
+				if iteration == 30*n {
+					return ErrNotConverged
+				}
+
+				g := d[l]
+				p := (d[l+1] - g) / (2 * e[l])
+				r := math.Hypot(p, 1)
+				if p < 0 {
+					r = -r
+				}
+				d[l] = e[l] / (p + r)
+				d[l+1] = e[l] * (p + r)
+				dl1 := d[l+1]
+				h := g - d[l]
+				for i := l + 2; i < n; i++ {
+					d[i] -= h
+				}
+				f += h
+
+				p = d[m]
+				c, c2, c3 := 1.0, 1.0, 1.0
+				el1 := e[l+1]
+				s, s2 := 0.0, 0.0
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*d[i] - s*g
+					d[i+1] = h + s*(c*g+s*d[i])
+
+					for k := 0; k < n; k++ {
+						h = v[k][i+1]
+						v[k][i+1] = s*v[k][i] + c*h
+						v[k][i] = c*v[k][i] - s*h
+					}
+				}
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				d[l] = c * p
+
+				if math.Abs(e[l]) <= epsilon*tst1 {
+					break
+				}
+			}
+		}
+		d[l] += f
+		e[l] = 0
+	}
+
+	// selection sort into ascending order
+	for i := 0; i < n-1; i++ {
+		k := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < p {
+				k = j
+				p = d[j]
+			}
+		}
+		if k != i {
+			d[k] = d[i]
+			d[i] = p
+			for j := 0; j < n; j++ {
+				v[j][i], v[j][k] = v[j][k], v[j][i]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package expm
+
+import "math"
+
+// dense a small row major matrix used for the Padé approximant
+type dense [][]float64
+
+func newDense(n int) dense {
+	s := make(dense, n)
+	for i := range s {
+		s[i] = make([]float64, n)
+	}
+	return s
+}
+
+func (s dense) multiply(m dense) dense {
+	n := len(s)
+	result := newDense(n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			v := s[i][k]
+			if v == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				result[i][j] += v * m[k][j]
+			}
+		}
+	}
+	return result
+}
+
+func (s dense) add(m dense) dense {
+	result := newDense(len(s))
+	for i := range s {
+		for j := range s[i] {
+			result[i][j] = s[i][j] + m[i][j]
+		}
+	}
+	return result
+}
+
+func (s dense) scale(alpha float64) dense {
+	result := newDense(len(s))
+	for i := range s {
+		for j := range s[i] {
+			result[i][j] = alpha * s[i][j]
+		}
+	}
+	return result
+}
+
+// norm the maximum absolute column sum
+func (s dense) norm() float64 {
+	m := 0.0
+	for j := range s {
+		sum := 0.0
+		for i := range s {
+			sum += math.Abs(s[i][j])
+		}
+		m = math.Max(m, sum)
+	}
+	return m
+}
+
+// sum x·A + y·B + z·C
+func sum(x float64, a dense, y float64, b dense, z float64, c dense) dense {
+	return a.scale(x).add(b.scale(y)).add(c.scale(z))
+}
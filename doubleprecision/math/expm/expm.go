@@ -0,0 +1,175 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package expm the matrix exponential, its action on a vector and integer matrix powers
+package expm
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/lu"
+)
+
+// Padé approximant coefficients and the largest ‖A‖₁ each degree is accurate for (Higham 2005)
+var (
+	pade3  = []float64{120, 60, 12, 1}
+	pade5  = []float64{30240, 15120, 3360, 420, 30, 1}
+	pade7  = []float64{17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1}
+	pade9  = []float64{17643225600, 8821612800, 2075673600, 302702400, 30270240, 2162160, 110880, 3960, 90, 1}
+	pade13 = []float64{64764752532480000, 32382376266240000, 7771770303897600, 1187353796428800, 129060195264000, 10559470521600, 670442572800, 33522128640, 1323241920, 40840800, 960960, 16380, 182, 1}
+
+	theta = []struct {
+		norm   float64
+		degree []float64
+	}{
+		{1.495585217958292e-2, pade3},
+		{2.539398330063230e-1, pade5},
+		{9.504178996162932e-1, pade7},
+		{2.097847961257068e0, pade9},
+	}
+	theta13 = 5.371920351148152
+)
+
+// Expm the exponential of a dense square matrix by scaling and squaring with a Padé approximant
+//  eᴬ = (e^(A/2ˢ))^(2ˢ)
+func Expm(ctx context.Context, a doubleprecision.Matrix) (doubleprecision.Matrix, error) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not find the exponential of a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	m := newDense(n)
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		m[r][c] = value
+	}
+
+	result, err := expm(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return doubleprecision.NewDenseMatrixFromArray(result), nil
+}
+
+func expm(ctx context.Context, a dense) (dense, error) {
+	n := len(a)
+	if n == 0 {
+		return a, nil
+	}
+
+	a1 := a.norm()
+	for _, t := range theta {
+		if a1 <= t.norm {
+			return pade(ctx, a, t.degree)
+		}
+	}
+
+	s := 0
+	if a1 > theta13 {
+		s = int(math.Ceil(math.Log2(a1 / theta13)))
+	}
+	scaled := a.scale(math.Pow(2, -float64(s)))
+
+	x, err := pade(ctx, scaled, pade13)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < s; i++ {
+		x = x.multiply(x)
+	}
+
+	return x, nil
+}
+
+// pade the [m/m] Padé approximant r(A) = (V - U)⁻¹(V + U), U holds the odd and V the even powers
+func pade(ctx context.Context, a dense, b []float64) (dense, error) {
+	n := len(a)
+	identity := newDense(n)
+	for i := 0; i < n; i++ {
+		identity[i][i] = 1
+	}
+
+	a2 := a.multiply(a)
+	var u, v dense
+	if len(b) == len(pade13) {
+		a4 := a2.multiply(a2)
+		a6 := a4.multiply(a2)
+		u = a.multiply(a6.multiply(sum(b[13], a6, b[11], a4, b[9], a2)).add(sum(b[7], a6, b[5], a4, b[3], a2)).add(identity.scale(b[1])))
+		v = a6.multiply(sum(b[12], a6, b[10], a4, b[8], a2)).add(sum(b[6], a6, b[4], a4, b[2], a2)).add(identity.scale(b[0]))
+	} else {
+		// accumulate the even powers A⁰, A², A⁴ ...
+		odd := identity.scale(b[1])
+		even := identity.scale(b[0])
+		power := identity
+		for k := 2; k < len(b); k += 2 {
+			power = power.multiply(a2)
+			odd = odd.add(power.scale(b[k+1]))
+			even = even.add(power.scale(b[k]))
+		}
+		u = a.multiply(odd)
+		v = even
+	}
+
+	p := doubleprecision.NewDenseMatrixFromArray(v.add(u))
+	q := doubleprecision.NewDenseMatrixFromArray(v.add(u.scale(-1)))
+
+	f, err := lu.Decompose(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := f.SolveMatrix(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newDense(n)
+	for r := 0; r < n; r++ {
+		result[r] = x.RowsAtToArray(r)
+	}
+	return result, nil
+}
+
+// Power raises a square matrix to a non-negative integer power by repeated squaring, the result has the type of A
+// when cancelled the context error is returned and no partial product
+func Power(ctx context.Context, a doubleprecision.Matrix, k int) (doubleprecision.Matrix, error) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not raise a non square matrix to a power %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	if k < 0 {
+		log.Panicf("Can not raise a matrix to a negative power %+v", k)
+	}
+
+	result := a.Copy()
+	result.Clear()
+	for i := 0; i < a.Rows(); i++ {
+		result.Set(i, i, 1)
+	}
+
+	base := a
+	for k > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if k%2 == 1 {
+			result = result.Multiply(base)
+		}
+		k /= 2
+		if k > 0 {
+			base = base.Multiply(base)
+		}
+	}
+
+	return result, nil
+}
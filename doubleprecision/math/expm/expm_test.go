@@ -0,0 +1,245 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package expm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/expm"
+)
+
+func equal(t *testing.T, name string, got, want doubleprecision.Matrix, tolerance float64) {
+	t.Helper()
+	for r := 0; r < want.Rows(); r++ {
+		for c := 0; c < want.Columns(); c++ {
+			if math.Abs(got.At(r, c)-want.At(r, c)) > tolerance*math.Max(1, math.Abs(want.At(r, c))) {
+				t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", name, r, c, got.At(r, c), want.At(r, c))
+			}
+		}
+	}
+}
+
+func TestExpm(t *testing.T) {
+	rotation := func(theta float64) ([][]float64, [][]float64) {
+		return [][]float64{
+				[]float64{0, -theta},
+				[]float64{theta, 0},
+			}, [][]float64{
+				[]float64{math.Cos(theta), -math.Sin(theta)},
+				[]float64{math.Sin(theta), math.Cos(theta)},
+			}
+	}
+
+	small, smallWant := rotation(0.01)
+	large, largeWant := rotation(20)
+
+	tests := []struct {
+		name string
+		s    [][]float64
+		want [][]float64
+	}{
+		{
+			name: "Diagonal",
+			s: [][]float64{
+				[]float64{1, 0},
+				[]float64{0, -2},
+			},
+			want: [][]float64{
+				[]float64{math.E, 0},
+				[]float64{0, math.Exp(-2)},
+			},
+		},
+		{
+			name: "Nilpotent",
+			s: [][]float64{
+				[]float64{0, 1},
+				[]float64{0, 0},
+			},
+			want: [][]float64{
+				[]float64{1, 1},
+				[]float64{0, 1},
+			},
+		},
+		{
+			name: "SmallRotation",
+			s:    small,
+			want: smallWant,
+		},
+		{
+			name: "LargeRotation",
+			s:    large,
+			want: largeWant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expm.Expm(context.Background(), doubleprecision.NewCSRMatrixFromArray(tt.s))
+			if err != nil {
+				t.Fatal(err)
+			}
+			equal(t, tt.name, got, doubleprecision.NewDenseMatrixFromArray(tt.want), 1e-10)
+		})
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	// generator of a continuous-time random walk on a cycle, rows sum to zero
+	n := 60
+	a := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a.Set(i, (i+1)%n, 1)
+		a.Set(i, (i+n-1)%n, 2)
+		a.Set(i, i, -3)
+	}
+
+	v := doubleprecision.NewDenseVector(n)
+	v.SetVec(0, 1)
+	v.SetVec(7, 0.5)
+
+	for _, time := range []float64{0.1, 2.5} {
+		e, err := expm.Expm(context.Background(), a.Scalar(time))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(context.Background(), e, v, nil, want)
+
+		got, err := expm.Multiply(context.Background(), a, time, v, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, "Multiply", got, want, 1e-8)
+	}
+}
+
+func TestMultiply_Dimension(t *testing.T) {
+	// a small subspace needs many short steps, the error estimate has to find them
+	n := 60
+	a := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a.Set(i, (i+1)%n, 1)
+		a.Set(i, (i+n-1)%n, 2)
+		a.Set(i, i, -3)
+	}
+
+	v := doubleprecision.NewDenseVector(n)
+	v.SetVec(0, 1)
+
+	for _, time := range []float64{2.5, -0.5} {
+		e, err := expm.Expm(context.Background(), a.Scalar(time))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := doubleprecision.NewDenseVector(n)
+		doubleprecision.MatrixVectorMultiply(context.Background(), e, v, nil, want)
+
+		got, err := expm.Multiply(context.Background(), a, time, v, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, "Multiply", got, want, 1e-8)
+	}
+}
+
+func TestMultiply_Scale(t *testing.T) {
+	// the path graph 0 - 1 - 2, a large v must not be taken as an invariant subspace
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		[]float64{0, 1, 0},
+		[]float64{1, 0, 1},
+		[]float64{0, 1, 0},
+	})
+
+	v := doubleprecision.NewDenseVector(3)
+	v.SetVec(0, 1e14)
+
+	e, err := expm.Expm(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := doubleprecision.NewDenseVector(3)
+	doubleprecision.MatrixVectorMultiply(context.Background(), e, v, nil, want)
+
+	got, err := expm.Multiply(context.Background(), a, 1, v, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if math.Abs(got.AtVec(i)-want.AtVec(i)) > 1e-8*1e14 {
+			t.Errorf("Multiply AtVec(%+v) = %+v, want %+v", i, got.AtVec(i), want.AtVec(i))
+		}
+	}
+}
+
+func TestPower(t *testing.T) {
+	array := [][]float64{
+		[]float64{1, 1, 0},
+		[]float64{0, 1, 1},
+		[]float64{1, 0, 1},
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.s.Copy()
+			for i := 1; i < 5; i++ {
+				want = want.Multiply(tt.s)
+			}
+			got, err := expm.Power(context.Background(), tt.s, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			equal(t, tt.name, got, want, 0)
+
+			identity, err := expm.Power(context.Background(), tt.s, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 3; c++ {
+					w := 0.0
+					if r == c {
+						w = 1
+					}
+					if identity.At(r, c) != w {
+						t.Errorf("%+v Power 0 At(%+v, %+v) = %+v, want %+v", tt.name, r, c, identity.At(r, c), w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPower_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 1},
+		[]float64{0, 1},
+	})
+	if got, err := expm.Power(ctx, a, 5); got != nil || err != context.Canceled {
+		t.Errorf("Power = %+v, %+v, want %+v, %+v", got, err, nil, context.Canceled)
+	}
+}
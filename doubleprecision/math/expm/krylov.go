@@ -0,0 +1,213 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package expm
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+	"github.com/rossmerr/graphblas/doubleprecision/math/norm"
+)
+
+// ErrTolerance the Krylov step was rejected too many times to reach the tolerance
+var ErrTolerance = errors.New("expm: the Krylov step did not reach the tolerance")
+
+// the local error allowed per unit of time relative to ‖v‖, and the rejections of a step before giving up
+const (
+	tolerance = 1e-10
+	rejects   = 10
+)
+
+// Multiply the action of the exponential of a sparse matrix on a vector without forming the exponential
+//  w = e^(tA)v
+// each time step projects A onto a Krylov subspace of the given dimension (30 when zero) with Arnoldi and exponentiates the small Hessenberg matrix,
+// the step size is chosen from the a-posteriori error estimate of Expokit (Sidje 1998) and a step is rejected and shortened when the estimate is too large
+func Multiply(ctx context.Context, a *doubleprecision.CSRMatrix, t float64, v doubleprecision.Vector, dimension int) (doubleprecision.Vector, error) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not find the exponential of a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	if v.Length() != n {
+		log.Panicf("Can not multiply found length mismatch %+v, %+v", n, v.Length())
+	}
+
+	if dimension <= 0 {
+		dimension = 30
+	}
+	m := dimension
+	if m > n {
+		m = n
+	}
+
+	op := operator.New(a)
+
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = v.AtVec(i)
+	}
+
+	anorm := norm.One(ctx, a)
+	scale := operator.Norm(w)
+	if anorm == 0 || scale == 0 || t == 0 {
+		return doubleprecision.NewDenseVectorFromArray(w), nil
+	}
+
+	sign := 1.0
+	if t < 0 {
+		sign = -1
+	}
+	total := math.Abs(t)
+	tol := tolerance * scale
+	// the estimate is never taken below the rounding error of a product with A
+	roundoff := anorm * scale * 1e-16
+
+	// the first step from the a-priori bound
+	xm := 1 / float64(m)
+	fact := math.Pow(float64(m+1)/math.E, float64(m+1)) * math.Sqrt(2*math.Pi*float64(m+1))
+	tau := round(math.Pow(fact*tol/(4*scale*anorm), xm) / anorm)
+
+	basis := make([][]float64, m+1)
+	for i := range basis {
+		basis[i] = make([]float64, n)
+	}
+	av := make([]float64, n)
+
+	for now := 0.0; now < total; {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if tau > total-now {
+			tau = total - now
+		}
+
+		beta := operator.Norm(w)
+		if beta == 0 {
+			break
+		}
+		for i := range w {
+			basis[0][i] = w[i] / beta
+		}
+
+		// the Hessenberg matrix is augmented by two rows and columns for the error estimate
+		h := newDense(m + 2)
+		k := m
+		breakdown := false
+		for j := 0; j < m; j++ {
+			next := basis[j+1]
+			op.Multiply(basis[j], next)
+			for i := 0; i <= j; i++ {
+				h[i][j] = operator.Dot(next, basis[i])
+				for r := range next {
+					next[r] -= h[i][j] * basis[i][r]
+				}
+			}
+
+			// the basis is unit length so the residual is measured against ‖A‖ and not the scale of v
+			length := operator.Norm(next)
+			if length <= 1e-12*anorm {
+				// happy breakdown, the subspace is invariant and the projection is exact
+				k = j + 1
+				breakdown = true
+				tau = total - now
+				break
+			}
+			h[j+1][j] = length
+			for r := range next {
+				next[r] /= length
+			}
+		}
+
+		size := k
+		avnorm := 0.0
+		if !breakdown {
+			size = m + 2
+			h[m+1][m] = 1
+			op.Multiply(basis[m], av)
+			avnorm = operator.Norm(av)
+		}
+
+		var e dense
+		local := 0.0
+		for reject := 0; ; reject++ {
+			small := newDense(size)
+			for i := 0; i < size; i++ {
+				for j := 0; j < size; j++ {
+					small[i][j] = sign * tau * h[i][j]
+				}
+			}
+
+			var err error
+			e, err = expm(ctx, small)
+			if err != nil {
+				return nil, err
+			}
+
+			if breakdown {
+				break
+			}
+
+			local = estimate(beta*math.Abs(e[m][0]), beta*math.Abs(e[m+1][0])*avnorm)
+			if local < roundoff {
+				local = roundoff
+			}
+			if local <= 1.2*tau*tol {
+				break
+			}
+			if reject == rejects {
+				return nil, ErrTolerance
+			}
+
+			tau = round(0.9 * tau * math.Pow(tau*tol/local, xm))
+		}
+
+		// the last basis vector carries the correction of the augmented matrix
+		used := k
+		if !breakdown {
+			used = m + 1
+		}
+		for r := range w {
+			w[r] = 0
+		}
+		for i := 0; i < used; i++ {
+			coefficient := beta * e[i][0]
+			for r := range w {
+				w[r] += coefficient * basis[i][r]
+			}
+		}
+
+		now += tau
+		if !breakdown {
+			tau = round(0.9 * tau * math.Pow(tau*tol/local, xm))
+		}
+	}
+
+	return doubleprecision.NewDenseVectorFromArray(w), nil
+}
+
+// estimate the local error from the two leading terms of the expansion of the error
+func estimate(first, second float64) float64 {
+	if first > 10*second {
+		return second
+	}
+	if first > second {
+		return first * second / (first - second)
+	}
+	return first
+}
+
+// round up to two significant digits
+func round(x float64) float64 {
+	s := math.Pow(10, math.Floor(math.Log10(x))-1)
+	return math.Ceil(x/s) * s
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package operator row-wise copies of a matrix and the vector kernels shared by the iterative methods
+package operator
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Operator row-wise copy of the stored elements of a matrix for repeated multiplication,
+// the columns of each row are in increasing order
+type Operator struct {
+	M      int // number of rows
+	N      int // number of columns
+	Cols   [][]int
+	Values [][]float64
+}
+
+// New returns a Operator
+func New(a doubleprecision.Matrix) *Operator {
+	s := &Operator{
+		M:      a.Rows(),
+		N:      a.Columns(),
+		Cols:   make([][]int, a.Rows()),
+		Values: make([][]float64, a.Rows()),
+	}
+
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		s.Cols[r] = append(s.Cols[r], c)
+		s.Values[r] = append(s.Values[r], value)
+	}
+
+	for r := range s.Cols {
+		if !sort.IntsAreSorted(s.Cols[r]) {
+			sort.Sort(&columnValues{s.Cols[r], s.Values[r]})
+		}
+	}
+
+	return s
+}
+
+type columnValues struct {
+	cols   []int
+	values []float64
+}
+
+func (s *columnValues) Len() int {
+	return len(s.cols)
+}
+
+func (s *columnValues) Less(i, j int) bool {
+	return s.cols[i] < s.cols[j]
+}
+
+func (s *columnValues) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// Multiply y = Ax
+func (s *Operator) Multiply(x, y []float64) {
+	for r := 0; r < s.M; r++ {
+		sum := 0.0
+		for i, c := range s.Cols[r] {
+			sum += s.Values[r][i] * x[c]
+		}
+		y[r] = sum
+	}
+}
+
+// TransposeMultiply y = Aᵀx
+func (s *Operator) TransposeMultiply(x, y []float64) {
+	for c := range y {
+		y[c] = 0
+	}
+	for r := 0; r < s.M; r++ {
+		for i, c := range s.Cols[r] {
+			y[c] += s.Values[r][i] * x[r]
+		}
+	}
+}
+
+// Diagonal the stored diagonal elements, missing elements are zero
+func (s *Operator) Diagonal() []float64 {
+	d := make([]float64, s.M)
+	for r := 0; r < s.M; r++ {
+		for i, c := range s.Cols[r] {
+			if c == r {
+				d[r] = s.Values[r][i]
+			}
+		}
+	}
+	return d
+}
+
+// Dot the inner product xᵀy
+func Dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// Norm the euclidean (l2) norm ‖x‖
+func Norm(x []float64) float64 {
+	return math.Sqrt(Dot(x, x))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package operator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+func TestOperator(t *testing.T) {
+	array := [][]float64{
+		[]float64{4, 0, 1},
+		[]float64{0, 2, 0},
+		[]float64{3, 0, 5},
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := operator.New(tt.s)
+			x := []float64{1, 2, 3}
+
+			y := make([]float64, 3)
+			op.Multiply(x, y)
+			if want := []float64{7, 4, 18}; !reflect.DeepEqual(y, want) {
+				t.Errorf("%+v Multiply = %+v, want %+v", tt.name, y, want)
+			}
+
+			op.TransposeMultiply(x, y)
+			if want := []float64{13, 4, 16}; !reflect.DeepEqual(y, want) {
+				t.Errorf("%+v TransposeMultiply = %+v, want %+v", tt.name, y, want)
+			}
+
+			if got, want := op.Diagonal(), []float64{4, 2, 5}; !reflect.DeepEqual(got, want) {
+				t.Errorf("%+v Diagonal = %+v, want %+v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func TestDot(t *testing.T) {
+	x := []float64{3, 4}
+	if got := operator.Dot(x, []float64{1, 2}); got != 11 {
+		t.Errorf("Dot = %+v, want %+v", got, 11)
+	}
+	if got := operator.Norm(x); got != 5 {
+		t.Errorf("Norm = %+v, want %+v", got, 5)
+	}
+}
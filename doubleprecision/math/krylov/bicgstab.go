@@ -0,0 +1,94 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package krylov
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// BiCGSTAB right preconditioned biconjugate gradient stabilized method for non-symmetric systems
+func BiCGSTAB(ctx context.Context, a *doubleprecision.CSRMatrix, b doubleprecision.Vector, settings *Settings) (*Result, error) {
+	m := newCSR(a)
+	s, bb, x, r := setup(m, b, settings)
+	normB := operator.Norm(bb)
+
+	history := []float64{relative(r, normB)}
+	if history[0] < s.Tolerance {
+		return result(x, 0, history, s.Tolerance), nil
+	}
+
+	shadow := make([]float64, m.N)
+	copy(shadow, r)
+
+	p := make([]float64, m.N)
+	v := make([]float64, m.N)
+	pHat := make([]float64, m.N)
+	sHat := make([]float64, m.N)
+	t := make([]float64, m.N)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+
+	iterations := 0
+	for iterations < s.MaxIterations {
+		select {
+		case <-ctx.Done():
+			return result(x, iterations, history, s.Tolerance), ctx.Err()
+		default:
+		}
+
+		iterations++
+
+		next := operator.Dot(shadow, r)
+		if next == 0 || omega == 0 {
+			return result(x, iterations, history, s.Tolerance), ErrBreakdown
+		}
+
+		beta := (next / rho) * (alpha / omega)
+		rho = next
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+
+		s.Preconditioner.Precondition(p, pHat)
+		m.Multiply(pHat, v)
+
+		sv := operator.Dot(shadow, v)
+		if sv == 0 {
+			return result(x, iterations, history, s.Tolerance), ErrBreakdown
+		}
+		alpha = rho / sv
+
+		// r now holds s = r - αv
+		axpy(-alpha, v, r)
+		axpy(alpha, pHat, x)
+
+		if relative(r, normB) < s.Tolerance {
+			history = append(history, relative(r, normB))
+			break
+		}
+
+		s.Preconditioner.Precondition(r, sHat)
+		m.Multiply(sHat, t)
+
+		tt := operator.Dot(t, t)
+		if tt == 0 {
+			return result(x, iterations, history, s.Tolerance), ErrBreakdown
+		}
+		omega = operator.Dot(t, r) / tt
+
+		axpy(omega, sHat, x)
+		axpy(-omega, t, r)
+
+		history = append(history, relative(r, normB))
+		if history[len(history)-1] < s.Tolerance {
+			break
+		}
+	}
+
+	return result(x, iterations, history, s.Tolerance), nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package krylov
+
+import (
+	"context"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// CG preconditioned conjugate gradient, A must be symmetric positive definite
+func CG(ctx context.Context, a *doubleprecision.CSRMatrix, b doubleprecision.Vector, settings *Settings) (*Result, error) {
+	m := newCSR(a)
+	s, bb, x, r := setup(m, b, settings)
+	normB := operator.Norm(bb)
+
+	history := []float64{relative(r, normB)}
+	if history[0] < s.Tolerance {
+		return result(x, 0, history, s.Tolerance), nil
+	}
+
+	z := make([]float64, m.N)
+	s.Preconditioner.Precondition(r, z)
+	p := make([]float64, m.N)
+	copy(p, z)
+	ap := make([]float64, m.N)
+	rz := operator.Dot(r, z)
+
+	iterations := 0
+	for iterations < s.MaxIterations {
+		select {
+		case <-ctx.Done():
+			return result(x, iterations, history, s.Tolerance), ctx.Err()
+		default:
+		}
+
+		iterations++
+
+		m.Multiply(p, ap)
+		pap := operator.Dot(p, ap)
+		if pap == 0 {
+			return result(x, iterations, history, s.Tolerance), ErrBreakdown
+		}
+		if pap < 0 {
+			return result(x, iterations, history, s.Tolerance), ErrNotPositiveDefinite
+		}
+
+		alpha := rz / pap
+		axpy(alpha, p, x)
+		axpy(-alpha, ap, r)
+
+		history = append(history, relative(r, normB))
+		if history[len(history)-1] < s.Tolerance {
+			break
+		}
+
+		s.Preconditioner.Precondition(r, z)
+		next := operator.Dot(r, z)
+		beta := next / rz
+		rz = next
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+
+	return result(x, iterations, history, s.Tolerance), nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package krylov
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// GMRES restarted generalized minimal residual method GMRES(m) with right preconditioning
+// the restart length m is taken from the settings, MaxIterations counts inner iterations
+func GMRES(ctx context.Context, a *doubleprecision.CSRMatrix, b doubleprecision.Vector, settings *Settings) (*Result, error) {
+	mat := newCSR(a)
+	s, bb, x, r := setup(mat, b, settings)
+	if settings == nil || settings.MaxIterations <= 0 {
+		s.MaxIterations = mat.N * s.Restart
+	}
+
+	normB := operator.Norm(bb)
+	n := mat.N
+	m := s.Restart
+	if m > n {
+		m = n
+	}
+
+	history := []float64{relative(r, normB)}
+	if history[0] < s.Tolerance {
+		return result(x, 0, history, s.Tolerance), nil
+	}
+
+	// Arnoldi basis, Hessenberg matrix and Givens rotations
+	basis := make([][]float64, m+1)
+	for i := range basis {
+		basis[i] = make([]float64, n)
+	}
+	z := make([][]float64, m)
+	for i := range z {
+		z[i] = make([]float64, n)
+	}
+	h := make([][]float64, m+1)
+	for i := range h {
+		h[i] = make([]float64, m)
+	}
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+
+	iterations := 0
+	for iterations < s.MaxIterations {
+		beta := operator.Norm(r)
+		for i := range r {
+			basis[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+
+		k := 0
+		for ; k < m && iterations < s.MaxIterations; k++ {
+			select {
+			case <-ctx.Done():
+				return result(x, iterations, history, s.Tolerance), ctx.Err()
+			default:
+			}
+
+			iterations++
+
+			s.Preconditioner.Precondition(basis[k], z[k])
+			w := basis[k+1]
+			mat.Multiply(z[k], w)
+
+			// modified Gram-Schmidt
+			for i := 0; i <= k; i++ {
+				h[i][k] = operator.Dot(w, basis[i])
+				axpy(-h[i][k], basis[i], w)
+			}
+			h[k+1][k] = operator.Norm(w)
+			if h[k+1][k] != 0 {
+				for i := range w {
+					w[i] /= h[k+1][k]
+				}
+			}
+
+			for i := 0; i < k; i++ {
+				h[i][k], h[i+1][k] = cs[i]*h[i][k]+sn[i]*h[i+1][k], -sn[i]*h[i][k]+cs[i]*h[i+1][k]
+			}
+
+			d := math.Hypot(h[k][k], h[k+1][k])
+			if d == 0 {
+				return result(x, iterations, history, s.Tolerance), ErrBreakdown
+			}
+			cs[k] = h[k][k] / d
+			sn[k] = h[k+1][k] / d
+			h[k][k] = d
+			h[k+1][k] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+
+			history = append(history, relative([]float64{g[k+1]}, normB))
+			if history[len(history)-1] < s.Tolerance {
+				k++
+				break
+			}
+		}
+
+		// back substitution on the triangular system Hy = g
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= h[i][j] * y[j]
+			}
+			y[i] /= h[i][i]
+		}
+		for i := 0; i < k; i++ {
+			axpy(y[i], z[i], x)
+		}
+
+		mat.Multiply(x, r)
+		for i := range r {
+			r[i] = bb[i] - r[i]
+		}
+		history[len(history)-1] = relative(r, normB)
+		if history[len(history)-1] < s.Tolerance {
+			break
+		}
+	}
+
+	return result(x, iterations, history, s.Tolerance), nil
+}
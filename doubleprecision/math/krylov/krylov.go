@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package krylov iterative Krylov subspace solvers for sparse linear systems Ax = b
+package krylov
+
+import (
+	"errors"
+	"log"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// ErrBreakdown the solver hit a zero divisor and can not continue
+var ErrBreakdown = errors.New("krylov: breakdown")
+
+// ErrNotPositiveDefinite the matrix is not symmetric positive definite
+var ErrNotPositiveDefinite = errors.New("krylov: matrix is not positive definite")
+
+// ErrZeroPivot a zero was found on the diagonal
+var ErrZeroPivot = errors.New("krylov: zero pivot")
+
+// Settings control the solvers
+type Settings struct {
+	// Tolerance on the relative residual ‖b - Ax‖ / ‖b‖, defaults to 1e-8
+	Tolerance float64
+
+	// MaxIterations defaults to the number of rows (times the restart length for GMRES)
+	MaxIterations int
+
+	// Restart the Krylov subspace size for GMRES, defaults to 30
+	Restart int
+
+	// Preconditioner defaults to none
+	Preconditioner Preconditioner
+
+	// X0 the initial guess, defaults to zero
+	X0 doubleprecision.Vector
+}
+
+// Result of a solve
+type Result struct {
+	// X the solution
+	X doubleprecision.Vector
+
+	// Iterations performed
+	Iterations int
+
+	// Residual the final relative residual
+	Residual float64
+
+	// History the relative residual at the start and after every iteration
+	History []float64
+
+	// Converged is true when the residual fell below the tolerance
+	Converged bool
+}
+
+// newCSR row-wise copy of a square matrix used by the solvers and preconditioners
+func newCSR(a *doubleprecision.CSRMatrix) *operator.Operator {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not solve a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	return operator.New(a)
+}
+
+// axpy y = αx + y
+func axpy(alpha float64, x, y []float64) {
+	for i := range x {
+		y[i] += alpha * x[i]
+	}
+}
+
+// setup validates the system and returns the settings with defaults, the initial guess and residual r = b - Ax
+func setup(a *operator.Operator, b doubleprecision.Vector, settings *Settings) (Settings, []float64, []float64, []float64) {
+	if b.Length() != a.N {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", a.N, b.Length())
+	}
+
+	s := Settings{}
+	if settings != nil {
+		s = *settings
+	}
+	if s.Tolerance <= 0 {
+		s.Tolerance = 1e-8
+	}
+	if s.Restart <= 0 {
+		s.Restart = 30
+	}
+	if s.MaxIterations <= 0 {
+		s.MaxIterations = a.N
+	}
+	if s.Preconditioner == nil {
+		s.Preconditioner = identity{}
+	}
+
+	x := make([]float64, a.N)
+	if s.X0 != nil {
+		for i := range x {
+			x[i] = s.X0.AtVec(i)
+		}
+	}
+
+	bb := make([]float64, a.N)
+	for i := range bb {
+		bb[i] = b.AtVec(i)
+	}
+
+	r := make([]float64, a.N)
+	a.Multiply(x, r)
+	for i := range r {
+		r[i] = bb[i] - r[i]
+	}
+
+	return s, bb, x, r
+}
+
+// relative residual, a zero right hand side is measured absolutely
+func relative(r []float64, normB float64) float64 {
+	if normB == 0 {
+		return operator.Norm(r)
+	}
+	return operator.Norm(r) / normB
+}
+
+func result(x []float64, iterations int, history []float64, tolerance float64) *Result {
+	residual := history[len(history)-1]
+	return &Result{
+		X:          doubleprecision.NewDenseVectorFromArray(x),
+		Iterations: iterations,
+		Residual:   residual,
+		History:    history,
+		Converged:  residual < tolerance,
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package krylov_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/krylov"
+)
+
+// tridiagonal returns a n×n matrix with lower, diagonal and upper on the three diagonals
+func tridiagonal(n int, lower, diagonal, upper float64) *doubleprecision.CSRMatrix {
+	a := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			a.Set(i, i-1, lower)
+		}
+		a.Set(i, i, diagonal)
+		if i < n-1 {
+			a.Set(i, i+1, upper)
+		}
+	}
+	return a
+}
+
+// system returns b = Ax for x = 1, 2, 3 ...
+func system(a *doubleprecision.CSRMatrix) (b, x doubleprecision.Vector) {
+	n := a.Rows()
+	x = doubleprecision.NewDenseVector(n)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, float64(i+1))
+	}
+	b = doubleprecision.NewDenseVector(n)
+	doubleprecision.MatrixVectorMultiply(context.Background(), a, x, nil, b)
+	return
+}
+
+type solver func(context.Context, *doubleprecision.CSRMatrix, doubleprecision.Vector, *krylov.Settings) (*krylov.Result, error)
+
+func preconditioners(t *testing.T, a *doubleprecision.CSRMatrix, symmetric bool) map[string]krylov.Preconditioner {
+	jacobi, err := krylov.NewJacobi(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ilu, err := krylov.NewILU(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := map[string]krylov.Preconditioner{
+		"None":   nil,
+		"Jacobi": jacobi,
+		"ILU":    ilu,
+	}
+
+	if symmetric {
+		ic, err := krylov.NewIncompleteCholesky(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p["IncompleteCholesky"] = ic
+	}
+
+	return p
+}
+
+func check(t *testing.T, name string, result *krylov.Result, err error, want doubleprecision.Vector) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%+v error = %+v", name, err)
+	}
+	if !result.Converged {
+		t.Errorf("%+v did not converge, residual %+v after %+v iterations", name, result.Residual, result.Iterations)
+	}
+	if len(result.History) == 0 || result.History[len(result.History)-1] != result.Residual {
+		t.Errorf("%+v History = %+v, want to end with %+v", name, result.History, result.Residual)
+	}
+	for i := 0; i < want.Length(); i++ {
+		if math.Abs(result.X.AtVec(i)-want.AtVec(i)) > 1e-6 {
+			t.Errorf("%+v AtVec(%+v) = %+v, want %+v", name, i, result.X.AtVec(i), want.AtVec(i))
+		}
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	a := tridiagonal(20, -1, 4, -1)
+	b, want := system(a)
+
+	solvers := map[string]solver{
+		"CG":       krylov.CG,
+		"BiCGSTAB": krylov.BiCGSTAB,
+		"GMRES":    krylov.GMRES,
+	}
+
+	for solverName, solve := range solvers {
+		for name, preconditioner := range preconditioners(t, a, true) {
+			t.Run(solverName+"/"+name, func(t *testing.T) {
+				result, err := solve(context.Background(), a, b, &krylov.Settings{
+					Tolerance:      1e-12,
+					Preconditioner: preconditioner,
+				})
+				check(t, solverName+"/"+name, result, err, want)
+			})
+		}
+	}
+}
+
+func TestNonSymmetric(t *testing.T) {
+	a := tridiagonal(20, -2, 5, -1)
+	a.Set(0, 5, 1)
+	b, want := system(a)
+
+	solvers := map[string]solver{
+		"BiCGSTAB": krylov.BiCGSTAB,
+		"GMRES":    krylov.GMRES,
+	}
+
+	for solverName, solve := range solvers {
+		for name, preconditioner := range preconditioners(t, a, false) {
+			t.Run(solverName+"/"+name, func(t *testing.T) {
+				result, err := solve(context.Background(), a, b, &krylov.Settings{
+					Tolerance:      1e-12,
+					Restart:        5,
+					MaxIterations:  200,
+					Preconditioner: preconditioner,
+				})
+				check(t, solverName+"/"+name, result, err, want)
+			})
+		}
+	}
+}
+
+func TestILU_Exact(t *testing.T) {
+	// ILU(0) of a tridiagonal matrix has no fill-in so it is the exact LU factorisation
+	a := tridiagonal(10, -2, 5, -1)
+	b, want := system(a)
+
+	ilu, err := krylov.NewILU(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := krylov.GMRES(context.Background(), a, b, &krylov.Settings{Tolerance: 1e-12, Preconditioner: ilu})
+	check(t, "GMRES/ILU", result, err, want)
+	if result.Iterations != 1 {
+		t.Errorf("Iterations = %+v, want %+v", result.Iterations, 1)
+	}
+}
+
+func TestIncompleteCholesky_NotPositiveDefinite(t *testing.T) {
+	a := tridiagonal(5, -1, -4, -1)
+	if _, err := krylov.NewIncompleteCholesky(a); err != krylov.ErrNotPositiveDefinite {
+		t.Errorf("NewIncompleteCholesky error = %+v, want %+v", err, krylov.ErrNotPositiveDefinite)
+	}
+}
+
+func TestCG_MaxIterations(t *testing.T) {
+	a := tridiagonal(50, -1, 2, -1)
+	b, _ := system(a)
+
+	result, err := krylov.CG(context.Background(), a, b, &krylov.Settings{Tolerance: 1e-14, MaxIterations: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Converged {
+		t.Errorf("CG Converged = %+v, want %+v", result.Converged, false)
+	}
+	if result.Iterations != 3 || len(result.History) != 4 {
+		t.Errorf("CG Iterations = %+v, History = %+v", result.Iterations, len(result.History))
+	}
+}
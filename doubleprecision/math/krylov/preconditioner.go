@@ -0,0 +1,205 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package krylov
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// Preconditioner approximates the inverse of the system matrix M⁻¹ ≈ A⁻¹
+type Preconditioner interface {
+	// Precondition solves Mz = r
+	Precondition(r, z []float64)
+}
+
+type identity struct{}
+
+func (identity) Precondition(r, z []float64) {
+	copy(z, r)
+}
+
+// Jacobi preconditioner, the inverse of the diagonal of A
+type Jacobi struct {
+	inverse []float64
+}
+
+// NewJacobi returns a Jacobi preconditioner
+func NewJacobi(a *doubleprecision.CSRMatrix) (*Jacobi, error) {
+	d := newCSR(a).Diagonal()
+	for i, v := range d {
+		if v == 0 {
+			return nil, ErrZeroPivot
+		}
+		d[i] = 1 / v
+	}
+
+	return &Jacobi{inverse: d}, nil
+}
+
+// Precondition solves Mz = r
+func (s *Jacobi) Precondition(r, z []float64) {
+	for i := range r {
+		z[i] = s.inverse[i] * r[i]
+	}
+}
+
+// IncompleteCholesky zero fill-in incomplete Cholesky preconditioner IC(0), A ≈ LLᵀ where L has the sparsity of the lower triangle of A
+type IncompleteCholesky struct {
+	l *operator.Operator
+}
+
+// NewIncompleteCholesky returns a IncompleteCholesky preconditioner, A must be symmetric positive definite
+func NewIncompleteCholesky(a *doubleprecision.CSRMatrix) (*IncompleteCholesky, error) {
+	m := newCSR(a)
+	l := &operator.Operator{M: m.N, N: m.N, Cols: make([][]int, m.N), Values: make([][]float64, m.N)}
+
+	for i := 0; i < m.N; i++ {
+		for k, j := range m.Cols[i] {
+			if j > i {
+				break
+			}
+			l.Cols[i] = append(l.Cols[i], j)
+			l.Values[i] = append(l.Values[i], m.Values[i][k])
+		}
+
+		if len(l.Cols[i]) == 0 || l.Cols[i][len(l.Cols[i])-1] != i {
+			return nil, ErrNotPositiveDefinite
+		}
+
+		for k, j := range l.Cols[i] {
+			// the sum over the shared columns of row i and row j left of j
+			sum := 0.0
+			p, q := 0, 0
+			for p < k && q < len(l.Cols[j]) && l.Cols[j][q] < j {
+				switch {
+				case l.Cols[i][p] < l.Cols[j][q]:
+					p++
+				case l.Cols[i][p] > l.Cols[j][q]:
+					q++
+				default:
+					sum += l.Values[i][p] * l.Values[j][q]
+					p++
+					q++
+				}
+			}
+
+			value := l.Values[i][k] - sum
+			if j < i {
+				l.Values[i][k] = value / l.Values[j][len(l.Values[j])-1]
+				continue
+			}
+
+			if value <= 0 {
+				return nil, ErrNotPositiveDefinite
+			}
+			l.Values[i][k] = math.Sqrt(value)
+		}
+	}
+
+	return &IncompleteCholesky{l: l}, nil
+}
+
+// Precondition solves LLᵀz = r
+func (s *IncompleteCholesky) Precondition(r, z []float64) {
+	l := s.l
+	copy(z, r)
+
+	// forward Ly = r
+	for i := 0; i < l.N; i++ {
+		last := len(l.Cols[i]) - 1
+		for k := 0; k < last; k++ {
+			z[i] -= l.Values[i][k] * z[l.Cols[i][k]]
+		}
+		z[i] /= l.Values[i][last]
+	}
+
+	// backward Lᵀz = y
+	for i := l.N - 1; i >= 0; i-- {
+		last := len(l.Cols[i]) - 1
+		z[i] /= l.Values[i][last]
+		for k := 0; k < last; k++ {
+			z[l.Cols[i][k]] -= l.Values[i][k] * z[i]
+		}
+	}
+}
+
+// ILU zero fill-in incomplete LU preconditioner ILU(0), A ≈ LU where L (unit diagonal) and U have the sparsity of A
+type ILU struct {
+	lu       *operator.Operator
+	diagonal []int
+}
+
+// NewILU returns a ILU preconditioner, every diagonal element of A must be stored
+func NewILU(a *doubleprecision.CSRMatrix) (*ILU, error) {
+	lu := newCSR(a)
+	diagonal := make([]int, lu.N)
+
+	for i := 0; i < lu.N; i++ {
+		d := sort.SearchInts(lu.Cols[i], i)
+		if d == len(lu.Cols[i]) || lu.Cols[i][d] != i {
+			return nil, ErrZeroPivot
+		}
+		diagonal[i] = d
+	}
+
+	for i := 1; i < lu.N; i++ {
+		for p := 0; p < diagonal[i]; p++ {
+			k := lu.Cols[i][p]
+			pivot := lu.Values[k][diagonal[k]]
+			if pivot == 0 {
+				return nil, ErrZeroPivot
+			}
+			lu.Values[i][p] /= pivot
+			factor := lu.Values[i][p]
+
+			// a_ij -= a_ik a_kj for j > k in the pattern of both rows
+			q := diagonal[k] + 1
+			for j := p + 1; j < len(lu.Cols[i]) && q < len(lu.Cols[k]); {
+				switch {
+				case lu.Cols[i][j] < lu.Cols[k][q]:
+					j++
+				case lu.Cols[i][j] > lu.Cols[k][q]:
+					q++
+				default:
+					lu.Values[i][j] -= factor * lu.Values[k][q]
+					j++
+					q++
+				}
+			}
+		}
+
+		if lu.Values[i][diagonal[i]] == 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+
+	return &ILU{lu: lu, diagonal: diagonal}, nil
+}
+
+// Precondition solves LUz = r
+func (s *ILU) Precondition(r, z []float64) {
+	lu := s.lu
+	copy(z, r)
+
+	// forward Ly = r, L has a unit diagonal
+	for i := 0; i < lu.N; i++ {
+		for k := 0; k < s.diagonal[i]; k++ {
+			z[i] -= lu.Values[i][k] * z[lu.Cols[i][k]]
+		}
+	}
+
+	// backward Uz = y
+	for i := lu.N - 1; i >= 0; i-- {
+		for k := s.diagonal[i] + 1; k < len(lu.Cols[i]); k++ {
+			z[i] -= lu.Values[i][k] * z[lu.Cols[i][k]]
+		}
+		z[i] /= lu.Values[i][s.diagonal[i]]
+	}
+}
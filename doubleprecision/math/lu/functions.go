@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lu
+
+import (
+	"context"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Determinant of a square matrix, a CSCMatrix is factorised with the sparse LU
+func Determinant(ctx context.Context, s doubleprecision.Matrix) (float64, error) {
+	if csc, ok := s.(*doubleprecision.CSCMatrix); ok {
+		f, err := DecomposeSparse(ctx, csc)
+		if err == ErrSingular {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		return f.Determinant(), nil
+	}
+
+	f, err := Decompose(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return f.Determinant(), nil
+}
+
+// Inverse of a square matrix
+func Inverse(ctx context.Context, s doubleprecision.Matrix) (doubleprecision.Matrix, error) {
+	f, err := Decompose(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return f.Inverse()
+}
+
+// Solve solves Ax = b, a CSCMatrix is factorised with the sparse LU
+func Solve(ctx context.Context, a doubleprecision.Matrix, b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	if csc, ok := a.(*doubleprecision.CSCMatrix); ok {
+		f, err := DecomposeSparse(ctx, csc)
+		if err != nil {
+			return nil, err
+		}
+		return f.Solve(b)
+	}
+
+	f, err := Decompose(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
+
+// Rank the number of linearly independent rows, found by gaussian elimination with partial pivoting to row echelon form
+func Rank(ctx context.Context, s doubleprecision.Matrix) int {
+	rows := s.Rows()
+	columns := s.Columns()
+
+	m := make([][]float64, rows)
+	largest := 0.0
+	for r := 0; r < rows; r++ {
+		m[r] = s.RowsAtToArray(r)
+		for _, v := range m[r] {
+			largest = math.Max(largest, math.Abs(v))
+		}
+	}
+	tolerance := math.Max(float64(rows), float64(columns)) * epsilon * largest
+
+	rank := 0
+	for c := 0; c < columns && rank < rows; c++ {
+		select {
+		case <-ctx.Done():
+			return rank
+		default:
+		}
+
+		p := rank
+		for i := rank + 1; i < rows; i++ {
+			if math.Abs(m[i][c]) > math.Abs(m[p][c]) {
+				p = i
+			}
+		}
+
+		if math.Abs(m[p][c]) <= tolerance {
+			continue
+		}
+
+		m[p], m[rank] = m[rank], m[p]
+		for i := rank + 1; i < rows; i++ {
+			factor := m[i][c] / m[rank][c]
+			for j := c; j < columns; j++ {
+				m[i][j] -= factor * m[rank][j]
+			}
+		}
+		rank++
+	}
+
+	return rank
+}
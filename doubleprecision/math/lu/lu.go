@@ -0,0 +1,254 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package lu LU factorisation with partial pivoting and the determinant, inverse, solve and rank built on it
+package lu
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// ErrSingular the matrix is singular to working precision
+var ErrSingular = errors.New("lu: matrix is singular")
+
+// epsilon machine precision for float64
+var epsilon = math.Nextafter(1, 2) - 1
+
+// LU factorisation of a dense square matrix with partial pivoting
+//  PA = LU
+type LU struct {
+	n         int
+	lu        [][]float64 // L strictly below the diagonal (the unit diagonal is implied), U on and above
+	pivot     []int       // row i of PA is row pivot[i] of A
+	sign      float64
+	tolerance float64
+}
+
+// Decompose factorises the square matrix, a singular matrix still factorises but can not be solved or inverted,
+// returns the context error when cancelled before the factorisation completes
+func Decompose(ctx context.Context, s doubleprecision.Matrix) (*LU, error) {
+	if s.Rows() != s.Columns() {
+		log.Panicf("Can not decompose a non square matrix %+v, %+v", s.Rows(), s.Columns())
+	}
+
+	n := s.Rows()
+	f := &LU{
+		n:     n,
+		lu:    make([][]float64, n),
+		pivot: make([]int, n),
+		sign:  1,
+	}
+
+	largest := 0.0
+	for r := 0; r < n; r++ {
+		f.lu[r] = s.RowsAtToArray(r)
+		f.pivot[r] = r
+		for _, v := range f.lu[r] {
+			largest = math.Max(largest, math.Abs(v))
+		}
+	}
+	f.tolerance = float64(n) * epsilon * largest
+
+	for k := 0; k < n; k++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(f.lu[i][k]) > math.Abs(f.lu[p][k]) {
+				p = i
+			}
+		}
+
+		if p != k {
+			f.lu[p], f.lu[k] = f.lu[k], f.lu[p]
+			f.pivot[p], f.pivot[k] = f.pivot[k], f.pivot[p]
+			f.sign = -f.sign
+		}
+
+		if f.lu[k][k] == 0 {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			f.lu[i][k] /= f.lu[k][k]
+			factor := f.lu[i][k]
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				f.lu[i][j] -= factor * f.lu[k][j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// Singular is true when a pivot is zero to working precision
+func (s *LU) Singular() bool {
+	for k := 0; k < s.n; k++ {
+		if math.Abs(s.lu[k][k]) <= s.tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// L the unit lower triangular factor
+func (s *LU) L() doubleprecision.Matrix {
+	l := doubleprecision.NewDenseMatrix(s.n, s.n)
+	for r := 0; r < s.n; r++ {
+		for c := 0; c < r; c++ {
+			l.Set(r, c, s.lu[r][c])
+		}
+		l.Set(r, r, 1)
+	}
+	return l
+}
+
+// U the upper triangular factor
+func (s *LU) U() doubleprecision.Matrix {
+	u := doubleprecision.NewDenseMatrix(s.n, s.n)
+	for r := 0; r < s.n; r++ {
+		for c := r; c < s.n; c++ {
+			u.Set(r, c, s.lu[r][c])
+		}
+	}
+	return u
+}
+
+// P the permutation matrix
+func (s *LU) P() doubleprecision.Matrix {
+	p := doubleprecision.NewCSRMatrix(s.n, s.n)
+	for r, c := range s.pivot {
+		p.Set(r, c, 1)
+	}
+	return p
+}
+
+// Determinant the product of the pivots
+func (s *LU) Determinant() float64 {
+	d := s.sign
+	for k := 0; k < s.n; k++ {
+		d *= s.lu[k][k]
+	}
+	return d
+}
+
+// solve overwrites x (already permuted) with the solution of LUx = x
+func (s *LU) solve(x []float64) {
+	for i := 0; i < s.n; i++ {
+		for j := 0; j < i; j++ {
+			x[i] -= s.lu[i][j] * x[j]
+		}
+	}
+
+	for i := s.n - 1; i >= 0; i-- {
+		for j := i + 1; j < s.n; j++ {
+			x[i] -= s.lu[i][j] * x[j]
+		}
+		x[i] /= s.lu[i][i]
+	}
+}
+
+// Solve solves Ax = b
+func (s *LU) Solve(b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	if b.Length() != s.n {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.n, b.Length())
+	}
+
+	if s.Singular() {
+		return nil, ErrSingular
+	}
+
+	x := make([]float64, s.n)
+	for i, p := range s.pivot {
+		x[i] = b.AtVec(p)
+	}
+	s.solve(x)
+
+	return doubleprecision.NewDenseVectorFromArray(x), nil
+}
+
+// SolveTranspose solves Aᵀx = b
+func (s *LU) SolveTranspose(b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	if b.Length() != s.n {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.n, b.Length())
+	}
+
+	if s.Singular() {
+		return nil, ErrSingular
+	}
+
+	z := make([]float64, s.n)
+	for i := range z {
+		z[i] = b.AtVec(i)
+	}
+
+	// Uᵀy = b
+	for i := 0; i < s.n; i++ {
+		for j := 0; j < i; j++ {
+			z[i] -= s.lu[j][i] * z[j]
+		}
+		z[i] /= s.lu[i][i]
+	}
+
+	// Lᵀz = y
+	for i := s.n - 1; i >= 0; i-- {
+		for j := i + 1; j < s.n; j++ {
+			z[i] -= s.lu[j][i] * z[j]
+		}
+	}
+
+	x := doubleprecision.NewDenseVector(s.n)
+	for i, p := range s.pivot {
+		x.SetVec(p, z[i])
+	}
+
+	return x, nil
+}
+
+// SolveMatrix solves AX = B for every column of B
+func (s *LU) SolveMatrix(b doubleprecision.Matrix) (doubleprecision.Matrix, error) {
+	if b.Rows() != s.n {
+		log.Panicf("Can not solve found rows mismatch %+v, %+v", s.n, b.Rows())
+	}
+
+	if s.Singular() {
+		return nil, ErrSingular
+	}
+
+	matrix := doubleprecision.NewDenseMatrix(s.n, b.Columns())
+	x := make([]float64, s.n)
+	for c := 0; c < b.Columns(); c++ {
+		for i, p := range s.pivot {
+			x[i] = b.At(p, c)
+		}
+		s.solve(x)
+		for r, v := range x {
+			matrix.Set(r, c, v)
+		}
+	}
+
+	return matrix, nil
+}
+
+// Inverse solves AX = I
+func (s *LU) Inverse() (doubleprecision.Matrix, error) {
+	identity := doubleprecision.NewCSCMatrix(s.n, s.n)
+	for i := 0; i < s.n; i++ {
+		identity.Set(i, i, 1)
+	}
+	return s.SolveMatrix(identity)
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lu_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/lu"
+)
+
+var array = [][]float64{
+	[]float64{0, 2, 0, 1},
+	[]float64{4, 0, 0, 3},
+	[]float64{0, 1, 5, 0},
+	[]float64{2, 0, 1, 6},
+}
+
+func equal(t *testing.T, name string, got, want doubleprecision.Matrix) {
+	t.Helper()
+	for r := 0; r < want.Rows(); r++ {
+		for c := 0; c < want.Columns(); c++ {
+			if math.Abs(got.At(r, c)-want.At(r, c)) > 1e-9 {
+				t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", name, r, c, got.At(r, c), want.At(r, c))
+			}
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := lu.Decompose(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			equal(t, tt.name, f.L().Multiply(f.U()), f.P().Multiply(tt.s))
+
+			if got := f.Determinant(); math.Abs(got-(-184)) > 1e-9 {
+				t.Errorf("%+v Determinant = %+v, want %+v", tt.name, got, -184)
+			}
+		})
+	}
+}
+
+func TestDecomposeSparse(t *testing.T) {
+	a := doubleprecision.NewCSCMatrixFromArray(array)
+
+	f, err := lu.DecomposeSparse(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equal(t, "SparseLU", f.L().Multiply(f.U()), f.P().Multiply(a).Multiply(f.Q()))
+
+	if got := f.Determinant(); math.Abs(got-(-184)) > 1e-9 {
+		t.Errorf("Determinant = %+v, want %+v", got, -184)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	x := doubleprecision.NewDenseVectorFromArray([]float64{1, -2, 3, 4})
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := doubleprecision.NewDenseVector(4)
+			doubleprecision.MatrixVectorMultiply(context.Background(), tt.s, x, nil, b)
+
+			got, err := lu.Solve(context.Background(), tt.s, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			equal(t, tt.name, got, x)
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := doubleprecision.NewDenseMatrixFromArray(array)
+	inverse, err := lu.Inverse(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity := doubleprecision.NewDenseMatrix(4, 4)
+	for i := 0; i < 4; i++ {
+		identity.Set(i, i, 1)
+	}
+	equal(t, "Inverse", a.Multiply(inverse), identity)
+}
+
+func TestSingular(t *testing.T) {
+	singular := [][]float64{
+		[]float64{1, 2, 3},
+		[]float64{2, 4, 6},
+		[]float64{1, 0, 1},
+	}
+
+	a := doubleprecision.NewDenseMatrixFromArray(singular)
+	if _, err := lu.Inverse(context.Background(), a); err != lu.ErrSingular {
+		t.Errorf("Inverse error = %+v, want %+v", err, lu.ErrSingular)
+	}
+
+	if got, err := lu.Determinant(context.Background(), a); got != 0 || err != nil {
+		t.Errorf("Determinant = %+v, %+v, want %+v, %+v", got, err, 0, nil)
+	}
+
+	csc := doubleprecision.NewCSCMatrixFromArray(singular)
+	if got, err := lu.Determinant(context.Background(), csc); got != 0 || err != nil {
+		t.Errorf("Determinant = %+v, %+v, want %+v, %+v", got, err, 0, nil)
+	}
+
+	if _, err := lu.DecomposeSparse(context.Background(), csc); err != lu.ErrSingular {
+		t.Errorf("DecomposeSparse error = %+v, want %+v", err, lu.ErrSingular)
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+		want int
+	}{
+		{
+			name: "Full",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+			want: 4,
+		},
+		{
+			name: "Deficient",
+			s: doubleprecision.NewCSRMatrixFromArray([][]float64{
+				[]float64{1, 2, 3, 4},
+				[]float64{2, 4, 6, 8},
+				[]float64{0, 0, 1, 1},
+			}),
+			want: 2,
+		},
+		{
+			name: "Zero",
+			s:    doubleprecision.NewCSRMatrix(3, 2),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lu.Rank(context.Background(), tt.s); got != tt.want {
+				t.Errorf("%+v Rank = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveTranspose(t *testing.T) {
+	a := doubleprecision.NewDenseMatrixFromArray(array)
+	x := doubleprecision.NewDenseVectorFromArray([]float64{1, -2, 3, 4})
+
+	b := doubleprecision.NewDenseVector(4)
+	doubleprecision.MatrixVectorMultiply(context.Background(), a.Transpose(), x, nil, b)
+
+	f, err := lu.Decompose(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := f.SolveTranspose(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "SolveTranspose", got, x)
+}
+
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := lu.Determinant(ctx, tt.s); err != context.Canceled {
+				t.Errorf("%+v Determinant = %+v, %+v, want %+v", tt.name, got, err, context.Canceled)
+			}
+		})
+	}
+
+	if f, err := lu.Decompose(ctx, doubleprecision.NewDenseMatrixFromArray(array)); f != nil || err != context.Canceled {
+		t.Errorf("Decompose = %+v, %+v, want %+v, %+v", f, err, nil, context.Canceled)
+	}
+}
+
+func TestDecomposeSparse_Ordering(t *testing.T) {
+	// a large tridiagonal matrix must factorise without fill
+	n := 5000
+	band := doubleprecision.NewCSCMatrix(n, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			band.Set(i, i-1, -1)
+		}
+		band.Set(i, i, 4)
+		if i < n-1 {
+			band.Set(i, i+1, -1)
+		}
+	}
+
+	f, err := lu.DecomposeSparse(context.Background(), band)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.L().Values() + f.U().Values(); got > 4*n {
+		t.Errorf("Band fill L + U = %+v, want at most %+v", got, 4*n)
+	}
+
+	// the five point Laplacian on a k×k grid, the natural ordering fills the whole band of width k
+	k := 40
+	m := k * k
+	grid := doubleprecision.NewCSCMatrix(m, m)
+	for r := 0; r < k; r++ {
+		for c := 0; c < k; c++ {
+			i := r*k + c
+			grid.Set(i, i, 4)
+			if r > 0 {
+				grid.Set(i, i-k, -1)
+			}
+			if r < k-1 {
+				grid.Set(i, i+k, -1)
+			}
+			if c > 0 {
+				grid.Set(i, i-1, -1)
+			}
+			if c < k-1 {
+				grid.Set(i, i+1, -1)
+			}
+		}
+	}
+
+	x := doubleprecision.NewDenseVector(m)
+	for i := 0; i < m; i++ {
+		x.SetVec(i, float64(i%7)-3)
+	}
+	b := doubleprecision.NewDenseVector(m)
+	doubleprecision.MatrixVectorMultiply(context.Background(), grid, x, nil, b)
+
+	f, err = lu.DecomposeSparse(context.Background(), grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.L().Values() + f.U().Values(); got >= m*k {
+		t.Errorf("Grid fill L + U = %+v, want less than %+v", got, m*k)
+	}
+
+	got, err := f.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "Grid", got, x)
+}
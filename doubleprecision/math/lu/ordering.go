@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lu
+
+// minimumDegree a fill-reducing column ordering, the approximate minimum degree ordering of the pattern of A + Aᵀ
+// q[k] is the column of A used as the k-th pivot column
+//
+// eliminated vertices are kept as elements of a quotient graph (as in AMD) so the fill is never formed,
+// the vertices are held in buckets by an upper bound on their degree so each pivot is found without a scan
+func minimumDegree(n int, columns [][]int) []int {
+	mark := make([]int, n)
+	stamp := 0
+
+	// the pattern of A + Aᵀ without the diagonal or duplicates
+	adjacency := make([][]int, n)
+	for c, rows := range columns {
+		for _, r := range rows {
+			if r != c {
+				adjacency[r] = append(adjacency[r], c)
+				adjacency[c] = append(adjacency[c], r)
+			}
+		}
+	}
+	for i, vertices := range adjacency {
+		stamp++
+		unique := vertices[:0]
+		for _, v := range vertices {
+			if mark[v] != stamp {
+				mark[v] = stamp
+				unique = append(unique, v)
+			}
+		}
+		adjacency[i] = unique
+	}
+
+	elements := make([][]int, n) // the elements adjacent to each vertex
+	boundary := make([][]int, n) // the vertices adjacent to each element
+	eliminated := make([]bool, n)
+	absorbed := make([]bool, n)
+	weight := make([]int, n)
+	weighed := make([]int, n)
+
+	// degree buckets as doubly linked lists
+	degree := make([]int, n)
+	head := make([]int, n+1)
+	next := make([]int, n)
+	previous := make([]int, n)
+	for d := range head {
+		head[d] = -1
+	}
+	insert := func(i, d int) {
+		degree[i] = d
+		previous[i] = -1
+		next[i] = head[d]
+		if head[d] >= 0 {
+			previous[head[d]] = i
+		}
+		head[d] = i
+	}
+	remove := func(i int) {
+		if previous[i] >= 0 {
+			next[previous[i]] = next[i]
+		} else {
+			head[degree[i]] = next[i]
+		}
+		if next[i] >= 0 {
+			previous[next[i]] = previous[i]
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		insert(i, len(adjacency[i]))
+	}
+
+	q := make([]int, 0, n)
+	minimum := 0
+	for k := 0; k < n; k++ {
+		for head[minimum] < 0 {
+			minimum++
+		}
+		p := head[minimum]
+		remove(p)
+		eliminated[p] = true
+		q = append(q, p)
+
+		// p becomes an element adjacent to its vertices and those of the elements it absorbs
+		stamp++
+		mark[p] = stamp
+		pivot := []int{}
+		for _, v := range adjacency[p] {
+			if !eliminated[v] && mark[v] != stamp {
+				mark[v] = stamp
+				pivot = append(pivot, v)
+			}
+		}
+		for _, e := range elements[p] {
+			if absorbed[e] {
+				continue
+			}
+			for _, v := range boundary[e] {
+				if !eliminated[v] && mark[v] != stamp {
+					mark[v] = stamp
+					pivot = append(pivot, v)
+				}
+			}
+			absorbed[e] = true
+			boundary[e] = nil
+		}
+		boundary[p] = pivot
+		adjacency[p] = nil
+		elements[p] = nil
+
+		// the number of vertices of every other element outside the new element
+		for _, i := range pivot {
+			for _, e := range elements[i] {
+				if absorbed[e] {
+					continue
+				}
+				if weighed[e] != stamp {
+					weighed[e] = stamp
+					vertices := boundary[e][:0]
+					for _, v := range boundary[e] {
+						if !eliminated[v] {
+							vertices = append(vertices, v)
+						}
+					}
+					boundary[e] = vertices
+					weight[e] = len(vertices)
+				}
+				weight[e]--
+			}
+		}
+
+		remaining := n - k - 2
+		for _, i := range pivot {
+			remove(i)
+
+			d := len(pivot) - 1
+			adjacent := elements[i][:0]
+			for _, e := range elements[i] {
+				if !absorbed[e] {
+					adjacent = append(adjacent, e)
+					d += weight[e]
+				}
+			}
+			elements[i] = append(adjacent, p)
+
+			// vertices reached through the new element are no longer kept as neighbours
+			vertices := adjacency[i][:0]
+			for _, v := range adjacency[i] {
+				if !eliminated[v] && mark[v] != stamp {
+					vertices = append(vertices, v)
+					d++
+				}
+			}
+			adjacency[i] = vertices
+
+			if d > remaining {
+				d = remaining
+			}
+			insert(i, d)
+			if d < minimum {
+				minimum = d
+			}
+		}
+	}
+
+	return q
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lu
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// column of a sparse factor
+type column struct {
+	rows   []int
+	values []float64
+}
+
+// SparseLU left-looking (Gilbert-Peierls) sparse LU factorisation with partial pivoting and a minimum degree column ordering
+//  PAQ = LU
+type SparseLU struct {
+	n    int
+	l    []column // unit lower triangular, the diagonal is stored first in each column
+	u    []column // upper triangular, the diagonal is stored last in each column
+	pinv []int    // row r of A is row pinv[r] of PAQ
+	q    []int    // column k of AQ is column q[k] of A
+}
+
+// DecomposeSparse factorises the square matrix, returns ErrSingular when no pivot can be found for a column
+func DecomposeSparse(ctx context.Context, s *doubleprecision.CSCMatrix) (*SparseLU, error) {
+	if s.Rows() != s.Columns() {
+		log.Panicf("Can not decompose a non square matrix %+v, %+v", s.Rows(), s.Columns())
+	}
+
+	n := s.Rows()
+	a := make([]column, n)
+	largest := 0.0
+	for c := 0; c < n; c++ {
+		for iterator := s.ColumnsAt(c).Enumerate(); iterator.HasNext(); {
+			r, _, value := iterator.Next()
+			a[c].rows = append(a[c].rows, r)
+			a[c].values = append(a[c].values, value)
+			largest = math.Max(largest, math.Abs(value))
+		}
+	}
+	tolerance := float64(n) * epsilon * largest
+
+	columns := make([][]int, n)
+	for c := range a {
+		columns[c] = a[c].rows
+	}
+
+	f := &SparseLU{
+		n:    n,
+		l:    make([]column, n),
+		u:    make([]column, n),
+		pinv: make([]int, n),
+		q:    minimumDegree(n, columns),
+	}
+	for i := range f.pinv {
+		f.pinv[i] = -1
+	}
+
+	x := make([]float64, n)
+	marked := make([]bool, n)
+
+	for k := 0; k < n; k++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		b := a[f.q[k]]
+		reach := f.reach(b.rows, marked)
+		for _, i := range reach {
+			x[i] = 0
+		}
+		for i, r := range b.rows {
+			x[r] = b.values[i]
+		}
+
+		// sparse triangular solve Lx = b over the reach in topological order
+		for _, j := range reach {
+			col := f.pinv[j]
+			if col < 0 {
+				continue
+			}
+			for i := 1; i < len(f.l[col].rows); i++ {
+				x[f.l[col].rows[i]] -= f.l[col].values[i] * x[j]
+			}
+		}
+
+		pivot := -1
+		for _, i := range reach {
+			if f.pinv[i] < 0 {
+				if pivot < 0 || math.Abs(x[i]) > math.Abs(x[pivot]) {
+					pivot = i
+				}
+			} else if x[i] != 0 {
+				f.u[k].rows = append(f.u[k].rows, f.pinv[i])
+				f.u[k].values = append(f.u[k].values, x[i])
+			}
+		}
+
+		if pivot < 0 || math.Abs(x[pivot]) <= tolerance {
+			return nil, ErrSingular
+		}
+
+		d := x[pivot]
+		f.u[k].rows = append(f.u[k].rows, k)
+		f.u[k].values = append(f.u[k].values, d)
+		f.pinv[pivot] = k
+
+		f.l[k].rows = append(f.l[k].rows, pivot)
+		f.l[k].values = append(f.l[k].values, 1)
+		for _, i := range reach {
+			if f.pinv[i] < 0 && x[i] != 0 {
+				f.l[k].rows = append(f.l[k].rows, i)
+				f.l[k].values = append(f.l[k].values, x[i]/d)
+			}
+		}
+	}
+
+	// L was built with the rows of A, renumber them into pivot order
+	for k := range f.l {
+		for i, r := range f.l[k].rows {
+			f.l[k].rows[i] = f.pinv[r]
+		}
+	}
+
+	return f, nil
+}
+
+// reach the rows of A reachable from the pattern of b through the graph of L, in topological order
+// while factorising L holds the rows of A, unpivoted rows are leaves
+func (s *SparseLU) reach(pattern []int, marked []bool) []int {
+	reach := make([]int, 0, len(pattern))
+	var visit func(j int)
+	visit = func(j int) {
+		marked[j] = true
+		if col := s.pinv[j]; col >= 0 {
+			for _, i := range s.l[col].rows[1:] {
+				if !marked[i] {
+					visit(i)
+				}
+			}
+		}
+		reach = append(reach, j)
+	}
+
+	for _, j := range pattern {
+		if !marked[j] {
+			visit(j)
+		}
+	}
+
+	for _, j := range reach {
+		marked[j] = false
+	}
+
+	// reverse post order
+	for i, j := 0, len(reach)-1; i < j; i, j = i+1, j-1 {
+		reach[i], reach[j] = reach[j], reach[i]
+	}
+
+	return reach
+}
+
+// L the unit lower triangular factor
+func (s *SparseLU) L() doubleprecision.Matrix {
+	return s.matrix(s.l)
+}
+
+// U the upper triangular factor
+func (s *SparseLU) U() doubleprecision.Matrix {
+	return s.matrix(s.u)
+}
+
+func (s *SparseLU) matrix(columns []column) doubleprecision.Matrix {
+	matrix := doubleprecision.NewCSCMatrix(s.n, s.n)
+	for c, col := range columns {
+		for i, r := range col.rows {
+			matrix.Set(r, c, col.values[i])
+		}
+	}
+	return matrix
+}
+
+// P the row permutation matrix
+func (s *SparseLU) P() doubleprecision.Matrix {
+	p := doubleprecision.NewCSRMatrix(s.n, s.n)
+	for r, k := range s.pinv {
+		p.Set(k, r, 1)
+	}
+	return p
+}
+
+// Q the column permutation matrix
+func (s *SparseLU) Q() doubleprecision.Matrix {
+	q := doubleprecision.NewCSRMatrix(s.n, s.n)
+	for k, c := range s.q {
+		q.Set(c, k, 1)
+	}
+	return q
+}
+
+// Determinant the product of the pivots and the signs of both permutations
+func (s *SparseLU) Determinant() float64 {
+	d := parity(s.pinv) * parity(s.q)
+	for k := range s.u {
+		d *= s.u[k].values[len(s.u[k].values)-1]
+	}
+	return d
+}
+
+// parity of a permutation, 1 when even -1 when odd
+func parity(p []int) float64 {
+	sign := 1.0
+	visited := make([]bool, len(p))
+	for i := range p {
+		if visited[i] {
+			continue
+		}
+		length := 0
+		for j := i; !visited[j]; j = p[j] {
+			visited[j] = true
+			length++
+		}
+		if length%2 == 0 {
+			sign = -sign
+		}
+	}
+	return sign
+}
+
+// Solve solves Ax = b
+func (s *SparseLU) Solve(b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	if b.Length() != s.n {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.n, b.Length())
+	}
+
+	y := make([]float64, s.n)
+	for r, k := range s.pinv {
+		y[k] = b.AtVec(r)
+	}
+
+	// Ly = Pb, column oriented
+	for k := 0; k < s.n; k++ {
+		for i := 1; i < len(s.l[k].rows); i++ {
+			y[s.l[k].rows[i]] -= s.l[k].values[i] * y[k]
+		}
+	}
+
+	// Uz = y, column oriented
+	for k := s.n - 1; k >= 0; k-- {
+		last := len(s.u[k].rows) - 1
+		y[k] /= s.u[k].values[last]
+		for i := 0; i < last; i++ {
+			y[s.u[k].rows[i]] -= s.u[k].values[i] * y[k]
+		}
+	}
+
+	x := doubleprecision.NewDenseVector(s.n)
+	for k, c := range s.q {
+		x.SetVec(c, y[k])
+	}
+
+	return x, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package norm matrix and vector norms, trace and condition number estimation
+package norm
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// One the maximum absolute column sum
+//  ‖A‖₁
+func One(ctx context.Context, s doubleprecision.Matrix) float64 {
+	sums := make([]float64, s.Columns())
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return 0
+		default:
+			_, c, value := iterator.Next()
+			sums[c] += math.Abs(value)
+		}
+	}
+
+	return maximum(sums)
+}
+
+// Infinity the maximum absolute row sum
+//  ‖A‖∞
+func Infinity(ctx context.Context, s doubleprecision.Matrix) float64 {
+	sums := make([]float64, s.Rows())
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return 0
+		default:
+			r, _, value := iterator.Next()
+			sums[r] += math.Abs(value)
+		}
+	}
+
+	return maximum(sums)
+}
+
+// Frobenius the square root of the sum of the squares of every element
+//  ‖A‖F
+func Frobenius(ctx context.Context, s doubleprecision.Matrix) float64 {
+	// scaled to avoid overflow in the sum of squares
+	scale := 0.0
+	sum := 1.0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return 0
+		default:
+			_, _, value := iterator.Next()
+			if value == 0 {
+				continue
+			}
+			a := math.Abs(value)
+			if scale < a {
+				sum = 1 + sum*(scale/a)*(scale/a)
+				scale = a
+			} else {
+				sum += (a / scale) * (a / scale)
+			}
+		}
+	}
+
+	return scale * math.Sqrt(sum)
+}
+
+// Max the largest absolute element
+//  ‖A‖max
+func Max(ctx context.Context, s doubleprecision.Matrix) float64 {
+	m := 0.0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return 0
+		default:
+			_, _, value := iterator.Next()
+			m = math.Max(m, math.Abs(value))
+		}
+	}
+
+	return m
+}
+
+// VectorOne the sum of the absolute elements
+//  ‖v‖₁
+func VectorOne(ctx context.Context, s doubleprecision.Vector) float64 {
+	sum := 0.0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return 0
+		default:
+			_, _, value := iterator.Next()
+			sum += math.Abs(value)
+		}
+	}
+
+	return sum
+}
+
+// VectorTwo the euclidean length
+//  ‖v‖₂
+func VectorTwo(ctx context.Context, s doubleprecision.Vector) float64 {
+	return Frobenius(ctx, s)
+}
+
+// VectorInfinity the largest absolute element
+//  ‖v‖∞
+func VectorInfinity(ctx context.Context, s doubleprecision.Vector) float64 {
+	return Max(ctx, s)
+}
+
+// Trace the sum of the diagonal of a square matrix
+func Trace(ctx context.Context, s doubleprecision.Matrix) float64 {
+	if s.Rows() != s.Columns() {
+		log.Panicf("Can not find the trace of a non square matrix %+v, %+v", s.Rows(), s.Columns())
+	}
+
+	sum := 0.0
+	if doubleprecision.IsSparseMatrix(s) {
+		for iterator := s.Enumerate(); iterator.HasNext(); {
+			select {
+			case <-ctx.Done():
+				return 0
+			default:
+				r, c, value := iterator.Next()
+				if r == c {
+					sum += value
+				}
+			}
+		}
+		return sum
+	}
+
+	for i := 0; i < s.Rows(); i++ {
+		sum += s.At(i, i)
+	}
+	return sum
+}
+
+func maximum(values []float64) float64 {
+	m := 0.0
+	for _, v := range values {
+		m = math.Max(m, v)
+	}
+	return m
+}
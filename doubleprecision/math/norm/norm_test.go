@@ -0,0 +1,149 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package norm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/norm"
+)
+
+var array = [][]float64{
+	[]float64{1, -2, 0},
+	[]float64{0, 3, 0},
+	[]float64{-4, 0, 2},
+}
+
+func TestMatrixNorms(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if got := norm.One(ctx, tt.s); got != 5 {
+				t.Errorf("%+v One = %+v, want %+v", tt.name, got, 5)
+			}
+			if got := norm.Infinity(ctx, tt.s); got != 6 {
+				t.Errorf("%+v Infinity = %+v, want %+v", tt.name, got, 6)
+			}
+			if got := norm.Frobenius(ctx, tt.s); math.Abs(got-math.Sqrt(34)) > 1e-12 {
+				t.Errorf("%+v Frobenius = %+v, want %+v", tt.name, got, math.Sqrt(34))
+			}
+			if got := norm.Max(ctx, tt.s); got != 4 {
+				t.Errorf("%+v Max = %+v, want %+v", tt.name, got, 4)
+			}
+			if got := norm.Trace(ctx, tt.s); got != 6 {
+				t.Errorf("%+v Trace = %+v, want %+v", tt.name, got, 6)
+			}
+		})
+	}
+}
+
+func TestVectorNorms(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Vector
+	}{
+		{
+			name: "DenseVector",
+			s:    doubleprecision.NewDenseVectorFromArray([]float64{3, 0, -4}),
+		},
+		{
+			name: "SparseVector",
+			s:    doubleprecision.NewSparseVectorFromArray([]float64{3, 0, -4}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if got := norm.VectorOne(ctx, tt.s); got != 7 {
+				t.Errorf("%+v VectorOne = %+v, want %+v", tt.name, got, 7)
+			}
+			if got := norm.VectorTwo(ctx, tt.s); math.Abs(got-5) > 1e-12 {
+				t.Errorf("%+v VectorTwo = %+v, want %+v", tt.name, got, 5)
+			}
+			if got := norm.VectorInfinity(ctx, tt.s); got != 4 {
+				t.Errorf("%+v VectorInfinity = %+v, want %+v", tt.name, got, 4)
+			}
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+		want float64
+	}{
+		{
+			name: "Diagonal",
+			s: doubleprecision.NewCSRMatrixFromArray([][]float64{
+				[]float64{100, 0},
+				[]float64{0, 0.5},
+			}),
+			want: 200,
+		},
+		{
+			// A⁻¹ = [[-2, 1], [1.5, -0.5]] so ‖A‖₁‖A⁻¹‖₁ = 6 × 3.5
+			name: "Dense",
+			s: doubleprecision.NewDenseMatrixFromArray([][]float64{
+				[]float64{1, 2},
+				[]float64{3, 4},
+			}),
+			want: 21,
+		},
+		{
+			name: "Singular",
+			s: doubleprecision.NewDenseMatrixFromArray([][]float64{
+				[]float64{1, 2},
+				[]float64{2, 4},
+			}),
+			want: math.Inf(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := norm.Condition(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 && got != tt.want {
+				t.Errorf("%+v Condition = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCondition_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 2},
+		[]float64{3, 4},
+	})
+	if _, err := norm.Condition(ctx, a); err != context.Canceled {
+		t.Errorf("Condition error = %+v, want %+v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package qr Householder QR factorisation and least squares
+package qr
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// ErrRankDeficient the matrix does not have full column rank
+var ErrRankDeficient = errors.New("qr: matrix is rank deficient")
+
+// epsilon machine precision for float64
+var epsilon = math.Nextafter(1, 2) - 1
+
+// QR factorisation of a m×n matrix (m ≥ n) using Householder reflections
+//  A = QR
+type QR struct {
+	m         int
+	n         int
+	qr        [][]float64 // Householder vectors on and below the diagonal, R above
+	diagonal  []float64   // the diagonal of R
+	tolerance float64
+}
+
+// Decompose factorises the matrix, it must have at least as many rows as columns,
+// returns the context error when cancelled before the factorisation completes
+func Decompose(ctx context.Context, s doubleprecision.Matrix) (*QR, error) {
+	m := s.Rows()
+	n := s.Columns()
+	if m < n {
+		log.Panicf("Can not decompose a matrix with fewer rows than columns %+v, %+v", m, n)
+	}
+
+	f := &QR{
+		m:        m,
+		n:        n,
+		qr:       make([][]float64, m),
+		diagonal: make([]float64, n),
+	}
+
+	largest := 0.0
+	for r := 0; r < m; r++ {
+		f.qr[r] = s.RowsAtToArray(r)
+		for _, v := range f.qr[r] {
+			largest = math.Max(largest, math.Abs(v))
+		}
+	}
+	f.tolerance = float64(m) * epsilon * largest
+
+	for k := 0; k < n; k++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		norm := 0.0
+		for i := k; i < m; i++ {
+			norm = math.Hypot(norm, f.qr[i][k])
+		}
+
+		if norm != 0 {
+			if f.qr[k][k] < 0 {
+				norm = -norm
+			}
+			for i := k; i < m; i++ {
+				f.qr[i][k] /= norm
+			}
+			f.qr[k][k]++
+
+			// apply the reflection to the remaining columns
+			for j := k + 1; j < n; j++ {
+				sum := 0.0
+				for i := k; i < m; i++ {
+					sum += f.qr[i][k] * f.qr[i][j]
+				}
+				sum = -sum / f.qr[k][k]
+				for i := k; i < m; i++ {
+					f.qr[i][j] += sum * f.qr[i][k]
+				}
+			}
+		}
+
+		f.diagonal[k] = -norm
+	}
+
+	return f, nil
+}
+
+// FullRank is true when R has no zero on its diagonal to working precision
+func (s *QR) FullRank() bool {
+	for _, v := range s.diagonal {
+		if math.Abs(v) <= s.tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// R the n×n upper triangular factor
+func (s *QR) R() doubleprecision.Matrix {
+	r := doubleprecision.NewDenseMatrix(s.n, s.n)
+	for i := 0; i < s.n; i++ {
+		r.Set(i, i, s.diagonal[i])
+		for j := i + 1; j < s.n; j++ {
+			r.Set(i, j, s.qr[i][j])
+		}
+	}
+	return r
+}
+
+// Q the m×n factor with orthonormal columns
+func (s *QR) Q() doubleprecision.Matrix {
+	q := doubleprecision.NewDenseMatrix(s.m, s.n)
+	for k := s.n - 1; k >= 0; k-- {
+		q.Set(k, k, 1)
+		for j := k; j < s.n; j++ {
+			if s.qr[k][k] == 0 {
+				continue
+			}
+			sum := 0.0
+			for i := k; i < s.m; i++ {
+				sum += s.qr[i][k] * q.At(i, j)
+			}
+			sum = -sum / s.qr[k][k]
+			for i := k; i < s.m; i++ {
+				q.Update(i, j, func(v float64) float64 {
+					return v + sum*s.qr[i][k]
+				})
+			}
+		}
+	}
+	return q
+}
+
+// Solve the least squares solution x minimising ‖Ax - b‖, the exact solution when A is square
+func (s *QR) Solve(b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	if b.Length() != s.m {
+		log.Panicf("Can not solve found length mismatch %+v, %+v", s.m, b.Length())
+	}
+
+	if !s.FullRank() {
+		return nil, ErrRankDeficient
+	}
+
+	y := make([]float64, s.m)
+	for i := range y {
+		y[i] = b.AtVec(i)
+	}
+
+	// y = Qᵀb
+	for k := 0; k < s.n; k++ {
+		sum := 0.0
+		for i := k; i < s.m; i++ {
+			sum += s.qr[i][k] * y[i]
+		}
+		sum = -sum / s.qr[k][k]
+		for i := k; i < s.m; i++ {
+			y[i] += sum * s.qr[i][k]
+		}
+	}
+
+	// Rx = y
+	x := y[:s.n]
+	for k := s.n - 1; k >= 0; k-- {
+		x[k] /= s.diagonal[k]
+		for i := 0; i < k; i++ {
+			x[i] -= x[k] * s.qr[i][k]
+		}
+	}
+
+	return doubleprecision.NewDenseVectorFromArray(x), nil
+}
+
+// LeastSquares solves the overdetermined system Ax ≈ b in the least squares sense
+func LeastSquares(ctx context.Context, a doubleprecision.Matrix, b doubleprecision.Vector) (doubleprecision.Vector, error) {
+	f, err := Decompose(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
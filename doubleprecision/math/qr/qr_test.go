@@ -0,0 +1,120 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package qr_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/qr"
+)
+
+func equal(t *testing.T, name string, got, want doubleprecision.Matrix) {
+	t.Helper()
+	for r := 0; r < want.Rows(); r++ {
+		for c := 0; c < want.Columns(); c++ {
+			if math.Abs(got.At(r, c)-want.At(r, c)) > 1e-9 {
+				t.Errorf("%+v At(%+v, %+v) = %+v, want %+v", name, r, c, got.At(r, c), want.At(r, c))
+			}
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	array := [][]float64{
+		[]float64{12, -51, 4},
+		[]float64{6, 167, -68},
+		[]float64{-4, 24, -41},
+		[]float64{1, 0, 2},
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := qr.Decompose(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := f.Q()
+			equal(t, tt.name, q.Multiply(f.R()), tt.s)
+
+			identity := doubleprecision.NewDenseMatrix(3, 3)
+			for i := 0; i < 3; i++ {
+				identity.Set(i, i, 1)
+			}
+			equal(t, tt.name, q.Transpose().Multiply(q), identity)
+		})
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// fit y = a + bx through (0, 1), (1, 3), (2, 5), (3, 7.5)
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 0},
+		[]float64{1, 1},
+		[]float64{1, 2},
+		[]float64{1, 3},
+	})
+	b := doubleprecision.NewDenseVectorFromArray([]float64{1, 3, 5, 7.5})
+
+	x, err := qr.LeastSquares(context.Background(), a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// normal equations give a = 0.9, b = 2.15
+	equal(t, "LeastSquares", x, doubleprecision.NewDenseVectorFromArray([]float64{0.9, 2.15}))
+}
+
+func TestRankDeficient(t *testing.T) {
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 2},
+		[]float64{2, 4},
+		[]float64{3, 6},
+	})
+	b := doubleprecision.NewDenseVectorFromArray([]float64{1, 2, 3})
+
+	if _, err := qr.LeastSquares(context.Background(), a, b); err != qr.ErrRankDeficient {
+		t.Errorf("LeastSquares error = %+v, want %+v", err, qr.ErrRankDeficient)
+	}
+}
+
+func TestDecompose_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	a := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		[]float64{1, 0},
+		[]float64{1, 1},
+		[]float64{1, 2},
+	})
+	if f, err := qr.Decompose(ctx, a); f != nil || err != context.Canceled {
+		t.Errorf("Decompose = %+v, %+v, want %+v, %+v", f, err, nil, context.Canceled)
+	}
+
+	b := doubleprecision.NewDenseVectorFromArray([]float64{1, 3, 5})
+	if _, err := qr.LeastSquares(ctx, a, b); err != context.Canceled {
+		t.Errorf("LeastSquares error = %+v, want %+v", err, context.Canceled)
+	}
+}
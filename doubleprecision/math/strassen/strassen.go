@@ -33,35 +33,16 @@ func MultiplyCrossoverPoint(ctx context.Context, a, b doubleprecision.Matrix, cr
 
 	size := n / 2
 
-	a11 := doubleprecision.NewDenseMatrix(size, size)
-	a12 := doubleprecision.NewDenseMatrix(size, size)
-	a21 := doubleprecision.NewDenseMatrix(size, size)
-	a22 := doubleprecision.NewDenseMatrix(size, size)
-
-	b11 := doubleprecision.NewDenseMatrix(size, size)
-	b12 := doubleprecision.NewDenseMatrix(size, size)
-	b21 := doubleprecision.NewDenseMatrix(size, size)
-	b22 := doubleprecision.NewDenseMatrix(size, size)
-
 	// dividing the matrices in 4 sub-matrices:
-	for r := 0; r < size; r++ {
-		for c := 0; c < size; c++ {
-			select {
-			case <-ctx.Done():
-				break
-			default:
-				a11.Set(r, c, a.At(r, c))           // top left
-				a12.Set(r, c, a.At(r, c+size))      // top right
-				a21.Set(r, c, a.At(r+size, c))      // bottom left
-				a22.Set(r, c, a.At(r+size, c+size)) // bottom right
-
-				b11.Set(r, c, b.At(r, c))           // top left
-				b12.Set(r, c, b.At(r, c+size))      // top right
-				b21.Set(r, c, b.At(r+size, c))      // bottom left
-				b22.Set(r, c, b.At(r+size, c+size)) // bottom right
-			}
-		}
-	}
+	a11 := doubleprecision.NewSubMatrix(a, 0, 0, size, size)       // top left
+	a12 := doubleprecision.NewSubMatrix(a, 0, size, size, size)    // top right
+	a21 := doubleprecision.NewSubMatrix(a, size, 0, size, size)    // bottom left
+	a22 := doubleprecision.NewSubMatrix(a, size, size, size, size) // bottom right
+
+	b11 := doubleprecision.NewSubMatrix(b, 0, 0, size, size)       // top left
+	b12 := doubleprecision.NewSubMatrix(b, 0, size, size, size)    // top right
+	b21 := doubleprecision.NewSubMatrix(b, size, 0, size, size)    // bottom left
+	b22 := doubleprecision.NewSubMatrix(b, size, size, size, size) // bottom right
 
 	out := make(chan *mPlace)
 
@@ -0,0 +1,287 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package svd truncated singular value decomposition of sparse matrices
+package svd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/internal/operator"
+)
+
+// ErrNotConverged the Jacobi sweeps did not converge
+var ErrNotConverged = errors.New("svd: did not converge")
+
+// epsilon machine precision for float64
+var epsilon = math.Nextafter(1, 2) - 1
+
+// sweeps the limit on Jacobi sweeps, convergence is quadratic so a few sweeps are normally enough
+const sweeps = 60
+
+// Settings control the randomized range finder, nil settings or a field that is zero use the defaults
+type Settings struct {
+	// Oversampling extra random vectors beyond k, default 10 when zero and none when negative
+	Oversampling int
+
+	// PowerIterations sharpen the range when singular values decay slowly, default 2 when zero and none when negative
+	PowerIterations int
+
+	// Seed of the random test matrix
+	Seed int64
+}
+
+// SVD the k largest singular values with their left and right singular vectors
+//  A ≈ UΣVᵀ
+type SVD struct {
+	// Values the singular values in descending order
+	Values []float64
+
+	// U the m×k left singular vectors
+	U doubleprecision.Matrix
+
+	// V the n×k right singular vectors
+	V doubleprecision.Matrix
+}
+
+// Truncated randomized SVD of any matrix (Halko, Martinsson and Tropp)
+// the range of A is captured by multiplying it with a random block, the SVD of the small projection B = QᵀA
+// is then found with one-sided Jacobi so the accuracy is not lost to the squared condition number of BBᵀ
+func Truncated(ctx context.Context, a doubleprecision.Matrix, k int, settings *Settings) (*SVD, error) {
+	m := a.Rows()
+	n := a.Columns()
+	if k < 1 || k > m || k > n {
+		log.Panicf("Can not find %+v singular values of a %+v×%+v matrix", k, m, n)
+	}
+
+	s := Settings{}
+	if settings != nil {
+		s = *settings
+	}
+	if s.Oversampling == 0 {
+		s.Oversampling = 10
+	} else if s.Oversampling < 0 {
+		s.Oversampling = 0
+	}
+	if s.PowerIterations == 0 {
+		s.PowerIterations = 2
+	}
+
+	l := k + s.Oversampling
+	if l > m {
+		l = m
+	}
+	if l > n {
+		l = n
+	}
+
+	op := operator.New(a)
+	random := rand.New(rand.NewSource(s.Seed))
+
+	omega := make([][]float64, l)
+	for j := range omega {
+		omega[j] = make([]float64, n)
+		for i := range omega[j] {
+			omega[j][i] = random.NormFloat64()
+		}
+	}
+
+	q := orthonormalise(multiplyBlock(op, omega))
+	for i := 0; i < s.PowerIterations; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		z := orthonormalise(transposeMultiplyBlock(op, q))
+		q = orthonormalise(multiplyBlock(op, z))
+	}
+
+	// Bᵀ = AᵀQ, the columns are the rows of the l×n projection B = QᵀA
+	bt := transposeMultiplyBlock(op, q)
+
+	// BᵀW = UΣ so B = WΣUᵀ and A ≈ (QW)ΣUᵀ
+	w, err := jacobi(ctx, bt)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, l)
+	values := make([]float64, l)
+	for j := range order {
+		order[j] = j
+		values[j] = operator.Norm(bt[j])
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	result := &SVD{
+		Values: make([]float64, k),
+		U:      doubleprecision.NewDenseMatrix(m, k),
+		V:      doubleprecision.NewDenseMatrix(n, k),
+	}
+
+	for p := 0; p < k; p++ {
+		j := order[p]
+		sigma := values[j]
+		result.Values[p] = sigma
+
+		for i := 0; i < l; i++ {
+			coefficient := w[j][i]
+			if coefficient == 0 {
+				continue
+			}
+			for r := 0; r < m; r++ {
+				if q[i][r] != 0 {
+					result.U.Update(r, p, func(v float64) float64 {
+						return v + coefficient*q[i][r]
+					})
+				}
+			}
+		}
+
+		if sigma == 0 {
+			continue
+		}
+		for c := 0; c < n; c++ {
+			if bt[j][c] != 0 {
+				result.V.Set(c, p, bt[j][c]/sigma)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// jacobi one-sided (Hestenes) Jacobi, rotates the columns of G in place until they are orthogonal
+// and returns the accumulated rotations W, GW has orthogonal columns whose norms are the singular values of G
+func jacobi(ctx context.Context, g [][]float64) ([][]float64, error) {
+	l := len(g)
+	w := make([][]float64, l)
+	for j := range w {
+		w[j] = make([]float64, l)
+		w[j][j] = 1
+	}
+
+	for sweep := 0; sweep < sweeps; sweep++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		rotated := false
+		for p := 0; p < l-1; p++ {
+			for q := p + 1; q < l; q++ {
+				alpha := operator.Dot(g[p], g[p])
+				beta := operator.Dot(g[q], g[q])
+				gamma := operator.Dot(g[p], g[q])
+				if gamma == 0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				// the rotation that zeros the off diagonal element of the 2×2 Gram matrix
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				rotate(g[p], g[q], c, s)
+				rotate(w[p], w[q], c, s)
+			}
+		}
+
+		if !rotated {
+			return w, nil
+		}
+	}
+
+	return nil, ErrNotConverged
+}
+
+// rotate applies a Givens rotation to the pair of columns x and y
+func rotate(x, y []float64, c, s float64) {
+	for i := range x {
+		a, b := x[i], y[i]
+		x[i] = c*a - s*b
+		y[i] = s*a + c*b
+	}
+}
+
+// Approximate the rank k approximation UΣVᵀ
+func (s *SVD) Approximate() doubleprecision.Matrix {
+	matrix := doubleprecision.NewDenseMatrix(s.U.Rows(), s.V.Rows())
+	for p, sigma := range s.Values {
+		for r := 0; r < s.U.Rows(); r++ {
+			u := s.U.At(r, p) * sigma
+			if u == 0 {
+				continue
+			}
+			for c := 0; c < s.V.Rows(); c++ {
+				v := s.V.At(c, p)
+				matrix.Update(r, c, func(value float64) float64 {
+					return value + u*v
+				})
+			}
+		}
+	}
+	return matrix
+}
+
+// multiplyBlock AX for every column of X
+func multiplyBlock(op *operator.Operator, x [][]float64) [][]float64 {
+	y := make([][]float64, len(x))
+	for j := range x {
+		y[j] = make([]float64, op.M)
+		op.Multiply(x[j], y[j])
+	}
+	return y
+}
+
+// transposeMultiplyBlock AᵀX for every column of X
+func transposeMultiplyBlock(op *operator.Operator, x [][]float64) [][]float64 {
+	y := make([][]float64, len(x))
+	for j := range x {
+		y[j] = make([]float64, op.N)
+		op.TransposeMultiply(x[j], y[j])
+	}
+	return y
+}
+
+// orthonormalise the columns in place with modified Gram-Schmidt, columns in the span of earlier ones become zero
+func orthonormalise(x [][]float64) [][]float64 {
+	for j := range x {
+		before := operator.Norm(x[j])
+		for pass := 0; pass < 2; pass++ {
+			for i := 0; i < j; i++ {
+				h := operator.Dot(x[i], x[j])
+				for r := range x[j] {
+					x[j][r] -= h * x[i][r]
+				}
+			}
+		}
+
+		after := operator.Norm(x[j])
+		if after <= 1e-12*before || after == 0 {
+			for r := range x[j] {
+				x[j][r] = 0
+			}
+			continue
+		}
+		for r := range x[j] {
+			x[j][r] /= after
+		}
+	}
+	return x
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package svd_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/svd"
+)
+
+// the singular values are 9, 4, 2 and 1
+var array = [][]float64{
+	[]float64{0, 0, 4, 0},
+	[]float64{0, 0, 0, 0},
+	[]float64{9, 0, 0, 0},
+	[]float64{0, 0, 0, 1},
+	[]float64{0, 2, 0, 0},
+}
+
+func TestTruncated(t *testing.T) {
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(array),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svd.Truncated(context.Background(), tt.s, 2, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []float64{9, 4}
+			for i, v := range want {
+				if math.Abs(got.Values[i]-v) > 1e-8 {
+					t.Errorf("%+v Values[%+v] = %+v, want %+v", tt.name, i, got.Values[i], v)
+				}
+			}
+
+			// the rank 2 approximation keeps only the two largest elements
+			approximate := got.Approximate()
+			for r := 0; r < 5; r++ {
+				for c := 0; c < 4; c++ {
+					v := array[r][c]
+					if v < 4 {
+						v = 0
+					}
+					if math.Abs(approximate.At(r, c)-v) > 1e-8 {
+						t.Errorf("%+v Approximate At(%+v, %+v) = %+v, want %+v", tt.name, r, c, approximate.At(r, c), v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTruncated_LowRank(t *testing.T) {
+	// rank 2, the outer products of (1, 2, 0, 1, 3)(1, 1, 0) and (0, 1, 1, 0, 0)(0, 2, 1)
+	a := doubleprecision.NewCSRMatrix(5, 3)
+	u1 := []float64{1, 2, 0, 1, 3}
+	v1 := []float64{1, 1, 0}
+	u2 := []float64{0, 1, 1, 0, 0}
+	v2 := []float64{0, 2, 1}
+	for r := 0; r < 5; r++ {
+		for c := 0; c < 3; c++ {
+			a.Set(r, c, u1[r]*v1[c]+u2[r]*v2[c])
+		}
+	}
+
+	got, err := svd.Truncated(context.Background(), a, 2, &svd.Settings{Oversampling: 1, PowerIterations: 1, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approximate := got.Approximate()
+	for r := 0; r < 5; r++ {
+		for c := 0; c < 3; c++ {
+			if math.Abs(approximate.At(r, c)-a.At(r, c)) > 1e-8 {
+				t.Errorf("Approximate At(%+v, %+v) = %+v, want %+v", r, c, approximate.At(r, c), a.At(r, c))
+			}
+		}
+	}
+
+	// singular vectors are orthonormal
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			u, v := 0.0, 0.0
+			for r := 0; r < 5; r++ {
+				u += got.U.At(r, i) * got.U.At(r, j)
+			}
+			for c := 0; c < 3; c++ {
+				v += got.V.At(c, i) * got.V.At(c, j)
+			}
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(u-want) > 1e-8 || math.Abs(v-want) > 1e-8 {
+				t.Errorf("UᵀU(%+v, %+v) = %+v, VᵀV = %+v, want %+v", i, j, u, v, want)
+			}
+		}
+	}
+}
+
+func TestTruncated_Defaults(t *testing.T) {
+	// a slowly decaying spectrum 1, 1/2, 1/3, ... so the range finder settings change the result
+	n := 40
+	a := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a.Set(i, (i*7)%n, 1/float64(i+1))
+	}
+
+	got, err := svd.Truncated(context.Background(), a, 2, &svd.Settings{Seed: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := svd.Truncated(context.Background(), a, 2, &svd.Settings{Oversampling: 10, PowerIterations: 2, Seed: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range want.Values {
+		if got.Values[i] != want.Values[i] {
+			t.Errorf("Values[%+v] = %+v, want %+v", i, got.Values[i], want.Values[i])
+		}
+	}
+
+	if math.Abs(got.Values[0]-1) > 1e-6 {
+		t.Errorf("Values[0] = %+v, want %+v", got.Values[0], 1)
+	}
+}
+
+func TestTruncated_NoPowerIterations(t *testing.T) {
+	// with l equal to the number of columns the range is found exactly without power iterations
+	a := doubleprecision.NewCSRMatrixFromArray(array)
+	got, err := svd.Truncated(context.Background(), a, 2, &svd.Settings{PowerIterations: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []float64{9, 4}
+	for i, v := range want {
+		if math.Abs(got.Values[i]-v) > 1e-8 {
+			t.Errorf("Values[%+v] = %+v, want %+v", i, got.Values[i], v)
+		}
+	}
+
+	// a slowly decaying spectrum where the power iterations change the result
+	n := 40
+	b := doubleprecision.NewCSRMatrix(n, n)
+	for i := 0; i < n; i++ {
+		b.Set(i, (i*7)%n, 1/float64(i+1))
+	}
+
+	none, err := svd.Truncated(context.Background(), b, 2, &svd.Settings{Oversampling: -1, PowerIterations: -1, Seed: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err := svd.Truncated(context.Background(), b, 2, &svd.Settings{Oversampling: -1, Seed: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if none.Values[1] == defaults.Values[1] {
+		t.Errorf("Values[1] = %+v, want it to differ from %+v", none.Values[1], defaults.Values[1])
+	}
+}
+
+func TestTruncated_IllConditioned(t *testing.T) {
+	// A = UΣVᵀ with rotated singular vectors, the Gram matrix BBᵀ would square 1e-9 below machine precision
+	sigma := []float64{1, 1e-9}
+	u := [][]float64{{math.Cos(0.3), -math.Sin(0.3)}, {math.Sin(0.3), math.Cos(0.3)}}
+	v := [][]float64{{math.Cos(0.7), -math.Sin(0.7)}, {math.Sin(0.7), math.Cos(0.7)}}
+	a := doubleprecision.NewDenseMatrix(2, 2)
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 2; c++ {
+			a.Set(r, c, u[r][0]*sigma[0]*v[c][0]+u[r][1]*sigma[1]*v[c][1])
+		}
+	}
+
+	// without power iterations Q is a random basis so B is not graded
+	got, err := svd.Truncated(context.Background(), a, 2, &svd.Settings{PowerIterations: -1, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range sigma {
+		if math.Abs(got.Values[i]-want) > 1e-6*want {
+			t.Errorf("Values[%+v] = %+v, want %+v", i, got.Values[i], want)
+		}
+	}
+}
@@ -9,6 +9,10 @@ import "github.com/rossmerr/graphblas/doubleprecision"
 
 // Symmetric matrix is a square matrix that is equal to its transpose
 func Symmetric(s doubleprecision.Matrix) bool {
+	if _, ok := s.(*doubleprecision.SymmetricMatrix); ok {
+		return true
+	}
+
 	r := s.Rows()
 	c := s.Columns()
 	if r != c {
@@ -46,6 +46,11 @@ func TestMatrix_Symmetric(t *testing.T) {
 			s:    doubleprecision.NewCSRMatrix(3, 3),
 			want: true,
 		},
+		{
+			name: "SymmetricMatrix",
+			s:    doubleprecision.NewSymmetricMatrix(3),
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
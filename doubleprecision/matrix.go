@@ -14,9 +14,15 @@ type Matrix interface {
 	// At returns the value of a matrix element at r-th, c-th
 	At(r, c int) float64
 
-	// Set sets the value at r-th, c-th of the matrix
+	// Set sets the value at r-th, c-th of the matrix, zero is stored like any other value
 	Set(r, c int, value float64)
 
+	// Has the element at r-th, c-th is stored
+	Has(r, c int) bool
+
+	// Remove removes the stored element at r-th, c-th
+	Remove(r, c int)
+
 	// Update does a At and Set on the matrix element at r-th, c-th
 	Update(r, c int, f func(float64) float64)
 
@@ -32,7 +38,7 @@ type Matrix interface {
 	// Copy copies the matrix
 	Copy() Matrix
 
-	// Enumerate iterates through all non-zero elements, order is not guaranteed
+	// Enumerate iterates through all stored elements, order is not guaranteed
 	Enumerate() Enumerate
 
 	// Map iterates and replace each element with the result of applying a function to its value
@@ -70,6 +76,9 @@ type Matrix interface {
 	// The number of elements in the matrix (non-zero counted for dense matrices)
 	Values() int
 
+	// NVals the number of stored elements in the matrix
+	NVals() int
+
 	// Clear removes all elements from a matrix
 	Clear()
 }
@@ -6,8 +6,11 @@
 package doubleprecision_test
 
 import (
+	"context"
+	"math/rand"
 	"testing"
 
+	"github.com/rossmerr/graphblas/binaryop/float64op"
 	"github.com/rossmerr/graphblas/doubleprecision"
 )
 
@@ -38,12 +41,48 @@ func TestMatrix_Update(t *testing.T) {
 			want:  2,
 			value: 2,
 		},
+		{
+			name:  "DCSCMatrix",
+			s:     doubleprecision.NewDCSCMatrix(2, 2),
+			want:  2,
+			value: 2,
+		},
 		{
 			name:  "CSRMatrix",
 			s:     doubleprecision.NewCSRMatrix(2, 2),
 			want:  2,
 			value: 2,
 		},
+		{
+			name:  "BSRMatrix",
+			s:     doubleprecision.NewBSRMatrix(2, 2, 1),
+			want:  2,
+			value: 2,
+		},
+		{
+			name:  "ELLMatrix",
+			s:     doubleprecision.NewELLMatrix(2, 2),
+			want:  2,
+			value: 2,
+		},
+		{
+			name:  "BitmapMatrix",
+			s:     doubleprecision.NewBitmapMatrix(2, 2),
+			want:  2,
+			value: 2,
+		},
+		{
+			name:  "DCSRMatrix",
+			s:     doubleprecision.NewDCSRMatrix(2, 2),
+			want:  2,
+			value: 2,
+		},
+		{
+			name:  "COOMatrix",
+			s:     doubleprecision.NewCOOMatrix(2, 2),
+			want:  2,
+			value: 2,
+		},
 		// Checks values get removed for sparse matrix
 		{
 			name:  "CSCMatrix",
@@ -51,12 +90,48 @@ func TestMatrix_Update(t *testing.T) {
 			want:  0,
 			value: 0,
 		},
+		{
+			name:  "DCSCMatrix",
+			s:     doubleprecision.NewDCSCMatrix(2, 2),
+			want:  0,
+			value: 0,
+		},
 		{
 			name:  "CSRMatrix",
 			s:     doubleprecision.NewCSRMatrix(2, 2),
 			want:  0,
 			value: 0,
 		},
+		{
+			name:  "BSRMatrix",
+			s:     doubleprecision.NewBSRMatrix(2, 2, 1),
+			want:  0,
+			value: 0,
+		},
+		{
+			name:  "ELLMatrix",
+			s:     doubleprecision.NewELLMatrix(2, 2),
+			want:  0,
+			value: 0,
+		},
+		{
+			name:  "BitmapMatrix",
+			s:     doubleprecision.NewBitmapMatrix(2, 2),
+			want:  0,
+			value: 0,
+		},
+		{
+			name:  "DCSRMatrix",
+			s:     doubleprecision.NewDCSRMatrix(2, 2),
+			want:  0,
+			value: 0,
+		},
+		{
+			name:  "COOMatrix",
+			s:     doubleprecision.NewCOOMatrix(2, 2),
+			want:  0,
+			value: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,12 +162,11 @@ func TestMatrix_SparseEnumerate(t *testing.T) {
 
 	dense := doubleprecision.NewDenseMatrix(3, 3)
 	setup(dense)
+	// zero is stored like any other value so every element set is enumerated
 	denseCount := 0
 	for iterator := dense.Enumerate(); iterator.HasNext(); {
-		_, _, value := iterator.Next()
-		if value != 0 {
-			denseCount++
-		}
+		iterator.Next()
+		denseCount++
 	}
 
 	tests := []struct {
@@ -103,10 +177,30 @@ func TestMatrix_SparseEnumerate(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(3, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(3, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(3, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(3, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(3, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(3, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(3, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -143,12 +237,11 @@ func TestMatrix_SparseMap(t *testing.T) {
 
 	dense := doubleprecision.NewDenseMatrix(3, 3)
 	setup(dense)
+	// zero is stored like any other value so every element set is enumerated
 	denseCount := 0
 	for iterator := dense.Enumerate(); iterator.HasNext(); {
-		_, _, value := iterator.Next()
-		if value != 0 {
-			denseCount++
-		}
+		iterator.Next()
+		denseCount++
 	}
 
 	tests := []struct {
@@ -159,10 +252,30 @@ func TestMatrix_SparseMap(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(3, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(3, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(3, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(3, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(3, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(3, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(3, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,10 +325,34 @@ func TestMatrix_ColumnsAt(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 2),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 2),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 2),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 2, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 2),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 2),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 2),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 2),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -253,10 +390,34 @@ func TestMatrix_RowAt(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 2),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 2),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 2),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 2, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 2),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 2),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 2),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 2),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -298,11 +459,41 @@ func TestMatrix_Scalar(t *testing.T) {
 			s:     doubleprecision.NewCSCMatrix(2, 2),
 			alpha: 2,
 		},
+		{
+			name:  "DCSCMatrix",
+			s:     doubleprecision.NewDCSCMatrix(2, 2),
+			alpha: 2,
+		},
 		{
 			name:  "CSRMatrix",
 			s:     doubleprecision.NewCSRMatrix(2, 2),
 			alpha: 2,
 		},
+		{
+			name:  "BSRMatrix",
+			s:     doubleprecision.NewBSRMatrix(2, 2, 1),
+			alpha: 2,
+		},
+		{
+			name:  "ELLMatrix",
+			s:     doubleprecision.NewELLMatrix(2, 2),
+			alpha: 2,
+		},
+		{
+			name:  "BitmapMatrix",
+			s:     doubleprecision.NewBitmapMatrix(2, 2),
+			alpha: 2,
+		},
+		{
+			name:  "DCSRMatrix",
+			s:     doubleprecision.NewDCSRMatrix(2, 2),
+			alpha: 2,
+		},
+		{
+			name:  "COOMatrix",
+			s:     doubleprecision.NewCOOMatrix(2, 2),
+			alpha: 2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -342,10 +533,34 @@ func TestMatrix_Negative(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 2),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 2),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 2),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 2, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 2),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 2),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 2),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 2),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -389,10 +604,34 @@ func TestMatrix_Transpose(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -436,10 +675,34 @@ func TestMatrix_Equal(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -482,10 +745,34 @@ func TestMatrix_NotEqual(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -575,10 +862,34 @@ func TestMatrix_Copy(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -627,10 +938,34 @@ func TestMatrix_Multiply(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 3),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -674,10 +1009,34 @@ func TestMatrix_Add(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 2),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 2),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 2),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 2, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 2),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 2),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 2),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 2),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -722,10 +1081,34 @@ func TestMatrix_Subtract(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 2),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 2),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 2),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 2, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 2),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrix(2, 2),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 2),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 2),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -761,12 +1144,37 @@ func TestMatrix_Size(t *testing.T) {
 		{
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrix(2, 3),
-			size: 5,
+			size: 6,
+		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(2, 3),
+			size: 6,
 		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrix(2, 3),
-			size: 5,
+			size: 6,
+		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrix(2, 3, 1),
+			size: 6,
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrix(2, 3),
+			size: 6,
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(2, 3),
+			size: 6,
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrix(2, 3),
+			size: 6,
 		},
 	}
 	for _, tt := range tests {
@@ -804,10 +1212,34 @@ func TestMatrix_FromArray(t *testing.T) {
 			name: "CSCMatrix",
 			s:    doubleprecision.NewCSCMatrixFromArray(setup),
 		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrixFromArray(setup),
+		},
 		{
 			name: "CSRMatrix",
 			s:    doubleprecision.NewCSRMatrixFromArray(setup),
 		},
+		{
+			name: "BSRMatrix",
+			s:    doubleprecision.NewBSRMatrixFromArray(setup, 1),
+		},
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrixFromArray(setup),
+		},
+		{
+			name: "BitmapMatrix",
+			s:    doubleprecision.NewBitmapMatrixFromArray(setup),
+		},
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrixFromArray(setup),
+		},
+		{
+			name: "COOMatrix",
+			s:    doubleprecision.NewCOOMatrixFromArray(setup),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -818,3 +1250,847 @@ func TestMatrix_FromArray(t *testing.T) {
 		})
 	}
 }
+
+func TestCOOMatrix_Build(t *testing.T) {
+	want := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		{0, 3, 0},
+		{1, 0, 0},
+		{0, 0, 7},
+	})
+
+	s := doubleprecision.NewCOOMatrix(3, 3)
+	s.Build(
+		[]int{2, 0, 1, 2, 0, 1, 1},
+		[]int{2, 1, 0, 2, 1, 2, 2},
+		[]float64{4, 1, 1, 3, 2, 5, -5},
+		float64op.Addition,
+	)
+
+	// the duplicates at (1, 2) cancel out to a stored zero
+	if s.Values() != 4 || !s.Has(1, 2) {
+		t.Errorf("COOMatrix Build Values = %+v, want %+v", s.Values(), 4)
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "COOMatrix",
+			s:    s,
+		},
+		{
+			name: "CSRMatrix",
+			s:    s.ToCSRMatrix(),
+		},
+		{
+			name: "CSCMatrix",
+			s:    s.ToCSCMatrix(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.s.NotEqual(want) {
+				t.Errorf("%+v Build = %+v, want %+v", tt.name, tt.s, want)
+			}
+			if tt.s.At(2, 2) != 7 {
+				t.Errorf("%+v Build At = %+v, want %+v", tt.name, tt.s.At(2, 2), 7)
+			}
+		})
+	}
+}
+
+func TestCOOMatrix_ToCSRMatrix_Unsorted(t *testing.T) {
+	s := doubleprecision.NewCOOMatrix(3, 3)
+	s.Set(2, 0, 1)
+	s.Set(0, 2, 2)
+	s.Set(1, 1, 3)
+	s.Set(0, 0, 4)
+
+	want := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		{4, 0, 2},
+		{0, 3, 0},
+		{1, 0, 0},
+	})
+
+	if got := s.ToCSRMatrix(); got.NotEqual(want) {
+		t.Errorf("COOMatrix ToCSRMatrix = %+v, want %+v", got, want)
+	}
+	if got := s.ToCSCMatrix(); got.NotEqual(want) {
+		t.Errorf("COOMatrix ToCSCMatrix = %+v, want %+v", got, want)
+	}
+}
+
+func TestMatrix_Hypersparse(t *testing.T) {
+	n := 1 << 32
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DCSRMatrix",
+			s:    doubleprecision.NewDCSRMatrix(n, n),
+		},
+		{
+			name: "DCSCMatrix",
+			s:    doubleprecision.NewDCSCMatrix(n, n),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.s.Set(n-1, 7, 1)
+			tt.s.Set(3, n-2, 2)
+			tt.s.Set(3, 5, 3)
+			tt.s.Remove(3, 5)
+
+			if tt.s.Values() != 2 {
+				t.Errorf("%+v Values = %+v, want %+v", tt.name, tt.s.Values(), 2)
+			}
+
+			transpose := tt.s.Transpose()
+			if got := transpose.At(7, n-1); got != 1 {
+				t.Errorf("%+v Transpose = %+v, want %+v", tt.name, got, 1)
+			}
+			if got := transpose.At(n-2, 3); got != 2 {
+				t.Errorf("%+v Transpose = %+v, want %+v", tt.name, got, 2)
+			}
+
+			if got := tt.s.RowsAt(3).AtVec(n - 2); got != 2 {
+				t.Errorf("%+v RowsAt = %+v, want %+v", tt.name, got, 2)
+			}
+
+			if got, want := tt.s.Size(), int(^uint(0)>>1); got != want {
+				t.Errorf("%+v Size = %+v, want %+v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func TestDCSRMatrix_Multiply_Hypersparse(t *testing.T) {
+	n := 1 << 32
+
+	s := doubleprecision.NewDCSRMatrix(n, n)
+	s.Set(n-1, 7, 2)
+	s.Set(7, n-2, 3)
+	s.Set(7, 9, 4)
+
+	got := s.Multiply(s)
+	want := map[[2]int]float64{
+		{n - 1, n - 2}: 6,
+		{n - 1, 9}:     8,
+	}
+	if got.Values() != len(want) {
+		t.Errorf("Multiply Values = %+v, want %+v", got.Values(), len(want))
+	}
+	for e, v := range want {
+		if got.At(e[0], e[1]) != v {
+			t.Errorf("Multiply At(%+v, %+v) = %+v, want %+v", e[0], e[1], got.At(e[0], e[1]), v)
+		}
+	}
+
+	x := doubleprecision.NewSparseVector(n)
+	x.SetVec(7, 5)
+	y := doubleprecision.NewSparseVector(n)
+	doubleprecision.MatrixVectorMultiply(context.Background(), s, x, nil, y)
+	if y.Values() != 1 || y.AtVec(n-1) != 10 {
+		t.Errorf("MatrixVectorMultiply = %+v, want %+v at %+v", y, 10, n-1)
+	}
+}
+
+func TestConform(t *testing.T) {
+	s := doubleprecision.NewCSRMatrix(64, 64)
+	s.Set(3, 4, 1)
+
+	hyper, ok := doubleprecision.Conform(s).(*doubleprecision.DCSRMatrix)
+	if !ok {
+		t.Fatalf("Conform = %T, want %T", doubleprecision.Conform(s), hyper)
+	}
+	if hyper.NotEqual(s) {
+		t.Errorf("Conform = %+v, want %+v", hyper, s)
+	}
+
+	for r := 0; r < 32; r++ {
+		hyper.Set(r, r, 1)
+	}
+
+	csr, ok := doubleprecision.Conform(hyper).(*doubleprecision.CSRMatrix)
+	if !ok {
+		t.Fatalf("Conform = %T, want %T", doubleprecision.Conform(hyper), csr)
+	}
+	if csr.NotEqual(hyper) {
+		t.Errorf("Conform = %+v, want %+v", csr, hyper)
+	}
+
+	c := doubleprecision.NewCSCMatrix(64, 64)
+	c.Set(3, 4, 1)
+	if _, ok := doubleprecision.Conform(c).(*doubleprecision.DCSCMatrix); !ok {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(c), &doubleprecision.DCSCMatrix{})
+	}
+}
+
+func TestConform_Hypersparse(t *testing.T) {
+	n := 1 << 32
+	s := doubleprecision.NewDCSRMatrix(n, n)
+	s.Set(0, 1, 1)
+	s.Set(n-1, n-1, 2)
+
+	if got, ok := doubleprecision.Conform(s).(*doubleprecision.DCSRMatrix); !ok || got != s {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(s), s)
+	}
+
+	c := doubleprecision.NewDCSCMatrix(n, n)
+	c.Set(0, 1, 1)
+	if got, ok := doubleprecision.Conform(c).(*doubleprecision.DCSCMatrix); !ok || got != c {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(c), c)
+	}
+}
+
+func TestConform_Result(t *testing.T) {
+	s := doubleprecision.NewCSRMatrix(64, 64)
+	s.Set(3, 4, 2)
+
+	identity := doubleprecision.NewCSRMatrix(64, 64)
+	for i := 0; i < 64; i++ {
+		identity.Set(i, i, 1)
+	}
+
+	// the result keeps the format of the receiver
+	product, ok := s.Multiply(identity).(*doubleprecision.CSRMatrix)
+	if !ok {
+		t.Fatalf("Multiply = %T, want %T", s.Multiply(identity), product)
+	}
+
+	// a single element is hypersparse once conformed
+	if got, ok := doubleprecision.Conform(product).(*doubleprecision.DCSRMatrix); !ok || got.At(3, 4) != 2 {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(product), got)
+	}
+
+	ones := doubleprecision.NewCSRMatrix(4, 4)
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			ones.Set(r, c, 1)
+		}
+	}
+	sum, ok := ones.Add(ones).(*doubleprecision.CSRMatrix)
+	if !ok {
+		t.Fatalf("Add = %T, want %T", ones.Add(ones), sum)
+	}
+
+	// every element stored is full once conformed
+	if got, ok := doubleprecision.Conform(sum).(*doubleprecision.DenseMatrix); !ok || got.At(1, 1) != 2 {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(sum), got)
+	}
+}
+
+func TestBitmapMatrix_Has(t *testing.T) {
+	s := doubleprecision.NewBitmapMatrix(2, 3)
+	s.Set(0, 1, 0)
+	s.Set(1, 2, 5)
+
+	if !s.Has(0, 1) {
+		t.Errorf("BitmapMatrix Has = %+v, want %+v", false, true)
+	}
+	if s.Has(0, 0) {
+		t.Errorf("BitmapMatrix Has = %+v, want %+v", true, false)
+	}
+	if s.Values() != 2 {
+		t.Errorf("BitmapMatrix Values = %+v, want %+v", s.Values(), 2)
+	}
+
+	count := 0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		iterator.Next()
+		count++
+	}
+	if count != 2 {
+		t.Errorf("BitmapMatrix Enumerate = %+v, want %+v", count, 2)
+	}
+
+	s.Remove(0, 1)
+	if s.Has(0, 1) || s.Values() != 1 {
+		t.Errorf("BitmapMatrix Remove = %+v, want %+v", s.Values(), 1)
+	}
+}
+
+func TestConform_Bitmap(t *testing.T) {
+	s := doubleprecision.NewCSRMatrix(4, 4)
+	s.Set(0, 0, 1)
+	s.Set(1, 2, 2)
+	s.Set(3, 3, 3)
+
+	bitmap, ok := doubleprecision.Conform(s).(*doubleprecision.BitmapMatrix)
+	if !ok {
+		t.Fatalf("Conform = %T, want %T", doubleprecision.Conform(s), bitmap)
+	}
+	if bitmap.NotEqual(s) {
+		t.Errorf("Conform = %+v, want %+v", bitmap, s)
+	}
+
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			bitmap.Update(r, c, func(v float64) float64 {
+				return v + 1
+			})
+		}
+	}
+
+	if _, ok := doubleprecision.Conform(bitmap).(*doubleprecision.DenseMatrix); !ok {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(bitmap), &doubleprecision.DenseMatrix{})
+	}
+
+	sparse := doubleprecision.NewBitmapMatrix(100, 100)
+	sparse.Set(5, 5, 1)
+	if _, ok := doubleprecision.Conform(sparse).(*doubleprecision.DCSRMatrix); !ok {
+		t.Errorf("Conform = %T, want %T", doubleprecision.Conform(sparse), &doubleprecision.DCSRMatrix{})
+	}
+
+	sparse.Set(6, 6, 0)
+	if got := doubleprecision.Conform(sparse); !got.Has(6, 6) {
+		t.Errorf("Conform Has = %+v, want %+v", false, true)
+	}
+}
+
+func TestMatrix_Has(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     doubleprecision.Matrix
+		nvals int
+	}{
+		{
+			name:  "DenseMatrix",
+			s:     doubleprecision.NewDenseMatrix(2, 2),
+			nvals: 3,
+		},
+		{
+			name:  "CSCMatrix",
+			s:     doubleprecision.NewCSCMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "DCSCMatrix",
+			s:     doubleprecision.NewDCSCMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "CSRMatrix",
+			s:     doubleprecision.NewCSRMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "BSRMatrix",
+			s:     doubleprecision.NewBSRMatrix(2, 2, 1),
+			nvals: 1,
+		},
+		{
+			name:  "ELLMatrix",
+			s:     doubleprecision.NewELLMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "DCSRMatrix",
+			s:     doubleprecision.NewDCSRMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "COOMatrix",
+			s:     doubleprecision.NewCOOMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "BitmapMatrix",
+			s:     doubleprecision.NewBitmapMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "DenseVector",
+			s:     doubleprecision.NewDenseVector(4),
+			nvals: 3,
+		},
+		{
+			name:  "SparseVector",
+			s:     doubleprecision.NewSparseVector(4),
+			nvals: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.s.Set(0, 0, 0)
+			tt.s.Set(1, 0, 5)
+
+			if !tt.s.Has(0, 0) {
+				t.Errorf("%+v Has = %+v, want %+v", tt.name, false, true)
+			}
+
+			if got := tt.s.At(1, 0); got != 5 {
+				t.Errorf("%+v At = %+v, want %+v", tt.name, got, 5)
+			}
+
+			tt.s.Remove(1, 0)
+
+			if got := tt.s.At(1, 0); got != 0 {
+				t.Errorf("%+v Remove = %+v, want %+v", tt.name, got, 0)
+			}
+
+			if tt.s.Has(1, 0) {
+				t.Errorf("%+v Has after Remove = %+v, want %+v", tt.name, true, false)
+			}
+
+			if got := tt.s.NVals(); got != tt.nvals {
+				t.Errorf("%+v NVals = %+v, want %+v", tt.name, got, tt.nvals)
+			}
+
+			var count int
+			for iterator := tt.s.Enumerate(); iterator.HasNext(); iterator.Next() {
+				count++
+			}
+			if count != tt.nvals {
+				t.Errorf("%+v Enumerate = %+v, want %+v", tt.name, count, tt.nvals)
+			}
+		})
+	}
+}
+
+func TestBSRMatrix_Blocks(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	build := func() (*doubleprecision.BSRMatrix, *doubleprecision.CSRMatrix) {
+		bsr := doubleprecision.NewBSRMatrix(9, 9, 3)
+		csr := doubleprecision.NewCSRMatrix(9, 9)
+		for i := 0; i < 25; i++ {
+			r, c, value := random.Intn(9), random.Intn(9), float64(random.Intn(5))
+			bsr.Set(r, c, value)
+			csr.Set(r, c, value)
+		}
+		return bsr, csr
+	}
+
+	a, aCSR := build()
+	b, bCSR := build()
+
+	// the union of the two, elements stored in both are summed
+	sum := aCSR.Copy()
+	for iterator := bCSR.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		sum.Update(r, c, func(v float64) float64 {
+			return v + value
+		})
+	}
+
+	tests := []struct {
+		name string
+		got  doubleprecision.Matrix
+		want doubleprecision.Matrix
+	}{
+		{
+			name: "Multiply",
+			got:  a.Multiply(b),
+			want: aCSR.Multiply(bCSR),
+		},
+		{
+			name: "Add",
+			got:  a.Add(b),
+			want: sum,
+		},
+		{
+			name: "Transpose",
+			got:  a.Transpose(),
+			want: aCSR.Transpose(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.got.(*doubleprecision.BSRMatrix); !ok {
+				t.Errorf("BSRMatrix %+v = %T, want %T", tt.name, tt.got, a)
+			}
+
+			if tt.got.NVals() != tt.want.NVals() {
+				t.Errorf("BSRMatrix %+v NVals = %+v, want %+v", tt.name, tt.got.NVals(), tt.want.NVals())
+			}
+
+			for iterator := tt.want.Enumerate(); iterator.HasNext(); {
+				r, c, value := iterator.Next()
+				if !tt.got.Has(r, c) || tt.got.At(r, c) != value {
+					t.Errorf("BSRMatrix %+v (%+v, %+v) = %+v, want %+v", tt.name, r, c, tt.got.At(r, c), value)
+				}
+			}
+		})
+	}
+}
+
+func TestStructuredMatrix(t *testing.T) {
+	data := [][]float64{
+		{4, 1, 0, 0},
+		{1, 5, 2, 0},
+		{0, 2, 6, 3},
+		{0, 0, 3, 7},
+	}
+	dense := doubleprecision.NewDenseMatrixFromArray(data)
+
+	diagonal := doubleprecision.NewDiagonalMatrixFromArray([]float64{4, 5, 6, 7})
+	lower := doubleprecision.NewTriangularMatrixFromArray(data, doubleprecision.Lower, false)
+	unit := doubleprecision.NewTriangularMatrixFromArray(data, doubleprecision.Upper, true)
+
+	tests := []struct {
+		name  string
+		s     doubleprecision.Matrix
+		want  [][]float64
+		nvals int
+	}{
+		{
+			name:  "SymmetricMatrix",
+			s:     doubleprecision.NewSymmetricMatrixFromArray(data),
+			want:  data,
+			nvals: 10,
+		},
+		{
+			name:  "BandMatrix",
+			s:     doubleprecision.NewBandMatrixFromArray(data, 1, 1),
+			want:  data,
+			nvals: 10,
+		},
+		{
+			name: "TriangularMatrix",
+			s:    lower,
+			want: [][]float64{
+				{4, 0, 0, 0},
+				{1, 5, 0, 0},
+				{0, 2, 6, 0},
+				{0, 0, 3, 7},
+			},
+			nvals: 7,
+		},
+		{
+			name: "TriangularMatrix unit",
+			s:    unit,
+			want: [][]float64{
+				{1, 1, 0, 0},
+				{0, 1, 2, 0},
+				{0, 0, 1, 3},
+				{0, 0, 0, 1},
+			},
+			nvals: 7,
+		},
+		{
+			name: "DiagonalMatrix",
+			s:    diagonal,
+			want: [][]float64{
+				{4, 0, 0, 0},
+				{0, 5, 0, 0},
+				{0, 0, 6, 0},
+				{0, 0, 0, 7},
+			},
+			nvals: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := doubleprecision.NewDenseMatrixFromArray(tt.want)
+			if tt.s.NotEqual(want) || want.NotEqual(tt.s) {
+				t.Errorf("%+v Equal = %+v, want %+v", tt.name, tt.s, want)
+			}
+
+			if tt.s.NVals() != tt.nvals {
+				t.Errorf("%+v NVals = %+v, want %+v", tt.name, tt.s.NVals(), tt.nvals)
+			}
+
+			count := 0
+			for iterator := tt.s.Enumerate(); iterator.HasNext(); {
+				r, c, value := iterator.Next()
+				if value != want.At(r, c) {
+					t.Errorf("%+v Enumerate (%+v, %+v) = %+v, want %+v", tt.name, r, c, value, want.At(r, c))
+				}
+				count++
+			}
+			if count != tt.nvals {
+				t.Errorf("%+v Enumerate = %+v, want %+v", tt.name, count, tt.nvals)
+			}
+
+			if transpose := tt.s.Transpose(); transpose.NotEqual(want.Transpose()) {
+				t.Errorf("%+v Transpose = %+v, want %+v", tt.name, transpose, want.Transpose())
+			}
+
+			if product := tt.s.Multiply(dense); product.NotEqual(want.Multiply(dense)) {
+				t.Errorf("%+v Multiply = %+v, want %+v", tt.name, product, want.Multiply(dense))
+			}
+
+			for r := 0; r < 4; r++ {
+				if row := tt.s.RowsAt(r); row.NotEqual(want.RowsAt(r)) {
+					t.Errorf("%+v RowsAt = %+v, want %+v", tt.name, row, want.RowsAt(r))
+				}
+				if column := tt.s.ColumnsAt(r); column.NotEqual(want.ColumnsAt(r)) {
+					t.Errorf("%+v ColumnsAt = %+v, want %+v", tt.name, column, want.ColumnsAt(r))
+				}
+			}
+		})
+	}
+
+	if product, ok := diagonal.Multiply(diagonal).(*doubleprecision.DiagonalMatrix); !ok || product.At(3, 3) != 49 {
+		t.Errorf("DiagonalMatrix Multiply = %+v, want %+v", product, 49)
+	}
+
+	if transpose, ok := lower.Transpose().(*doubleprecision.TriangularMatrix); !ok || transpose.Triangle() != doubleprecision.Upper {
+		t.Errorf("TriangularMatrix Transpose = %+v, want %+v", transpose, doubleprecision.Upper)
+	}
+}
+
+func TestStructuredMatrix_RightOperand(t *testing.T) {
+	identity := doubleprecision.NewSymmetricMatrix(2)
+	identity.Set(0, 0, 1)
+	identity.Set(1, 1, 1)
+
+	upper := doubleprecision.NewTriangularMatrixFromArray([][]float64{
+		{1, 1},
+		{0, 1},
+	}, doubleprecision.Upper, false)
+
+	band := doubleprecision.NewBandMatrixFromArray([][]float64{
+		{1, 1},
+		{1, 1},
+	}, 1, 0)
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+		m    doubleprecision.Matrix
+		want [][]float64
+	}{
+		{
+			name: "CSRMatrix - SymmetricMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray([][]float64{{0, 1}, {0, 0}}),
+			m:    doubleprecision.NewSymmetricMatrix(2),
+			want: [][]float64{{0, 1}, {0, 0}},
+		},
+		{
+			name: "DenseMatrix - SymmetricMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray([][]float64{{1, 2}, {3, 4}}),
+			m:    identity,
+			want: [][]float64{{0, 2}, {3, 3}},
+		},
+		{
+			name: "DenseMatrix - TriangularMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray([][]float64{{1, 2}, {3, 4}}),
+			m:    upper,
+			want: [][]float64{{0, 1}, {3, 3}},
+		},
+		{
+			name: "DenseMatrix - DiagonalMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray([][]float64{{1, 2}, {3, 4}}),
+			m:    doubleprecision.NewDiagonalMatrixFromArray([]float64{1, 1}),
+			want: [][]float64{{0, 2}, {3, 3}},
+		},
+		{
+			name: "CSRMatrix - BandMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray([][]float64{{1, 2}, {3, 4}}),
+			m:    band,
+			want: [][]float64{{0, 2}, {2, 3}},
+		},
+		{
+			name: "SymmetricMatrix - DiagonalMatrix",
+			s:    identity,
+			m:    doubleprecision.NewDiagonalMatrixFromArray([]float64{1, 2}),
+			want: [][]float64{{0, 0}, {0, -1}},
+		},
+		{
+			name: "TransposeView - DenseMatrix",
+			s:    doubleprecision.NewTransposeView(upper),
+			m:    doubleprecision.NewDenseMatrixFromArray([][]float64{{1, 2}, {3, 4}}),
+			want: [][]float64{{0, -2}, {-2, -3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.s.Subtract(tt.m)
+			for r := range tt.want {
+				for c := range tt.want[r] {
+					if got.At(r, c) != tt.want[r][c] {
+						t.Errorf("%+v Subtract At(%+v, %+v) = %+v, want %+v", tt.name, r, c, got.At(r, c), tt.want[r][c])
+					}
+				}
+			}
+
+			negative := tt.m.Negative()
+			for r := 0; r < tt.m.Rows(); r++ {
+				for c := 0; c < tt.m.Columns(); c++ {
+					if negative.At(r, c) != -tt.m.At(r, c) {
+						t.Errorf("%+v Negative At(%+v, %+v) = %+v, want %+v", tt.name, r, c, negative.At(r, c), -tt.m.At(r, c))
+					}
+				}
+			}
+		})
+	}
+
+	// the result of a structured matrix is general so it can be changed outside the structure
+	negative := doubleprecision.NewDiagonalMatrixFromArray([]float64{1, 2}).Negative()
+	negative.Set(1, 0, 5)
+	if got := negative.At(1, 0); got != 5 {
+		t.Errorf("Negative Set = %+v, want %+v", got, 5)
+	}
+}
+
+func TestSymmetricMatrix_Set(t *testing.T) {
+	s := doubleprecision.NewSymmetricMatrix(3)
+	s.Set(2, 0, 5)
+
+	if s.At(0, 2) != 5 || s.At(2, 0) != 5 {
+		t.Errorf("SymmetricMatrix Set = %+v, want %+v", s.At(0, 2), 5)
+	}
+
+	s.Remove(0, 2)
+	if s.Has(2, 0) {
+		t.Errorf("SymmetricMatrix Remove = %+v, want %+v", true, false)
+	}
+}
+
+func TestSymmetricMatrix_RowsAt(t *testing.T) {
+	s := doubleprecision.NewSymmetricMatrix(4)
+	s.Set(3, 1, 2)
+	s.Set(0, 3, 1)
+	s.Set(3, 3, 4)
+	s.Set(2, 3, 3)
+	s.Set(1, 2, 5)
+	s.Remove(2, 3)
+
+	want := []float64{1, 2, 0, 4}
+	got := s.RowsAtToArray(3)
+	for c, v := range want {
+		if got[c] != v {
+			t.Errorf("SymmetricMatrix RowsAtToArray(3)[%+v] = %+v, want %+v", c, got[c], v)
+		}
+	}
+
+	copied := s.Copy()
+	s.Clear()
+	if row := s.RowsAt(3); row.NVals() != 0 {
+		t.Errorf("SymmetricMatrix RowsAt(3) NVals = %+v, want %+v", row.NVals(), 0)
+	}
+
+	row := copied.RowsAt(3)
+	for c, v := range want {
+		if row.AtVec(c) != v || row.HasVec(c) != (v != 0) {
+			t.Errorf("SymmetricMatrix Copy RowsAt(3) AtVec(%+v) = %+v, want %+v", c, row.AtVec(c), v)
+		}
+	}
+}
+
+func TestMatrix_Views(t *testing.T) {
+	data := [][]float64{
+		{1, 2, 0, 4},
+		{0, 6, 7, 0},
+		{9, 0, 11, 12},
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    doubleprecision.NewDenseMatrixFromArray(data),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(data),
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(data),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := []struct {
+				name string
+				got  doubleprecision.Matrix
+				want [][]float64
+			}{
+				{
+					name: "SubMatrix",
+					got:  doubleprecision.NewSubMatrix(tt.s, 1, 1, 2, 3),
+					want: [][]float64{
+						{6, 7, 0},
+						{0, 11, 12},
+					},
+				},
+				{
+					name: "TransposeView",
+					got:  doubleprecision.NewTransposeView(tt.s),
+					want: [][]float64{
+						{1, 0, 9},
+						{2, 6, 0},
+						{0, 7, 11},
+						{4, 0, 12},
+					},
+				},
+				{
+					name: "PermutedView",
+					got:  doubleprecision.NewPermutedView(tt.s, []int{2, 0, 1}, []int{3, 2, 1, 0}),
+					want: [][]float64{
+						{12, 11, 0, 9},
+						{4, 0, 2, 1},
+						{0, 7, 6, 0},
+					},
+				},
+				{
+					name: "DiagonalView",
+					got:  doubleprecision.NewDiagonalView(tt.s, 1),
+					want: [][]float64{{2}, {7}, {12}},
+				},
+			}
+			for _, view := range views {
+				want := doubleprecision.NewDenseMatrixFromArray(view.want)
+				if view.got.NotEqual(want) {
+					t.Errorf("%+v %+v = %+v, want %+v", tt.name, view.name, view.got, want)
+				}
+
+				if copy := view.got.Copy(); copy.NotEqual(want) {
+					t.Errorf("%+v %+v Copy = %+v, want %+v", tt.name, view.name, copy, want)
+				}
+
+				for r := 0; r < want.Rows(); r++ {
+					if row := view.got.RowsAt(r); row.NotEqual(want.RowsAt(r)) {
+						t.Errorf("%+v %+v RowsAt = %+v, want %+v", tt.name, view.name, row, want.RowsAt(r))
+					}
+				}
+
+				for c := 0; c < want.Columns(); c++ {
+					if column := view.got.ColumnsAt(c); column.NotEqual(want.ColumnsAt(c)) {
+						t.Errorf("%+v %+v ColumnsAt = %+v, want %+v", tt.name, view.name, column, want.ColumnsAt(c))
+					}
+				}
+
+				if sum := view.got.Add(want); sum.NotEqual(want.Scalar(2)) {
+					t.Errorf("%+v %+v Add = %+v, want %+v", tt.name, view.name, sum, want.Scalar(2))
+				}
+			}
+		})
+	}
+}
+
+func TestSubMatrix_Shared(t *testing.T) {
+	s := doubleprecision.NewCSRMatrix(4, 4)
+	view := doubleprecision.NewSubMatrix(s, 2, 2, 2, 2)
+
+	s.Set(3, 2, 5)
+	if view.At(1, 0) != 5 || view.NVals() != 1 {
+		t.Errorf("SubMatrix At = %+v, want %+v", view.At(1, 0), 5)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SubMatrix Set = %+v, want %+v", nil, "panic")
+		}
+	}()
+	view.Set(0, 0, 1)
+}
+
+func TestSubMatrix_Has(t *testing.T) {
+	view := doubleprecision.NewSubMatrix(doubleprecision.NewCSRMatrix(4, 4), 2, 2, 2, 2)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SubMatrix Has = %+v, want %+v", nil, "panic")
+		}
+	}()
+	view.Has(2, 0)
+}
@@ -22,6 +22,11 @@ func NewMutexMatrix(matrix Matrix) *MutexMatrix {
 	}
 }
 
+// wrapped the guarded matrix
+func (s *MutexMatrix) wrapped() Matrix {
+	return s.matrix
+}
+
 // Columns the number of columns of the matrix
 func (s *MutexMatrix) Columns() int {
 	return s.matrix.Columns()
@@ -56,6 +61,22 @@ func (s *MutexMatrix) Set(r, c int, value float64) {
 	s.matrix.Set(r, c, value)
 }
 
+// Has the element at r-th, c-th is stored
+func (s *MutexMatrix) Has(r, c int) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.matrix.Has(r, c)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *MutexMatrix) Remove(r, c int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.matrix.Remove(r, c)
+}
+
 // ColumnsAt return the columns at c-th
 func (s *MutexMatrix) ColumnsAt(c int) Vector {
 	s.RLock()
@@ -162,6 +183,14 @@ func (s *MutexMatrix) Values() int {
 	return s.matrix.Values()
 }
 
+// NVals the number of stored elements in the matrix
+func (s *MutexMatrix) NVals() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.matrix.NVals()
+}
+
 // Clear removes all elements from a matrix
 func (s *MutexMatrix) Clear() {
 	s.RLock()
@@ -37,20 +37,28 @@ func multiply(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matr
 		for c := 0; c < m.Columns(); c++ {
 			column := m.ColumnsAt(c)
 
+			// an element is only stored when a pair of stored elements meet
 			sum := 0.0
-			for l := 0; l < rows.Length(); l++ {
+			stored := false
+			for iterator := rows.Enumerate(); iterator.HasNext(); {
 				select {
 				case <-ctx.Done():
 					return
 				default:
-					vC := column.AtVec(l)
-					vR := rows.AtVec(l)
-					sum += vR * vC
+					l, _, vR := iterator.Next()
+					if column.HasVec(l) {
+						sum += vR * column.AtVec(l)
+						stored = true
+					}
 				}
 			}
 
 			if !mask.Element(r, c) {
-				matrix.Set(r, c, sum)
+				if stored {
+					matrix.Set(r, c, sum)
+				} else if matrix.Has(r, c) {
+					matrix.Remove(r, c)
+				}
 			}
 		}
 
@@ -61,7 +69,12 @@ func multiply(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matr
 //
 // mxm
 func MatrixMatrixMultiply(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
-	multiply(ctx, s, m, mask, matrix)
+	switch a := s.(type) {
+	case *DCSRMatrix:
+		a.multiply(ctx, m, mask, matrix)
+	default:
+		multiply(ctx, s, m, mask, matrix)
+	}
 }
 
 // VectorMatrixMultiply multiplies a vector by a matrix
@@ -75,71 +88,20 @@ func VectorMatrixMultiply(ctx context.Context, s Vector, m Matrix, mask GraphBLA
 //
 // mxv
 func MatrixVectorMultiply(ctx context.Context, s Matrix, m Vector, mask GraphBLAS.Mask, vector Vector) {
-	multiply(ctx, s, m, mask, vector)
+	switch matrix := s.(type) {
+	case *ELLMatrix:
+		matrix.ell.multiplyVector(ctx, m, mask, vector)
+	case *SELLMatrix:
+		matrix.ell.multiplyVector(ctx, m, mask, vector)
+	case *DCSRMatrix:
+		matrix.multiply(ctx, m, mask, vector)
+	default:
+		multiply(ctx, s, m, mask, vector)
+	}
 }
 
 func elementWiseMultiply(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
-	var iterator Enumerate
-	var source Matrix
-
-	if mask == nil {
-		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
-	}
-
-	if mask.Rows() != matrix.Rows() {
-		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
-	}
-
-	if mask.Columns() != matrix.Columns() {
-		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
-	}
-
-	target := func(r, c int, value float64) {
-		v := source.At(r, c)
-		if value == v {
-			if !mask.Element(r, c) {
-				matrix.Set(r, c, value)
-			}
-		}
-	}
-
-	// when the matrix is the same object of s or m we use the setSource func to self update
-	setSource := func(r, c int, value float64) {
-		if !mask.Element(r, c) {
-			source.Update(r, c, func(v float64) float64 {
-				if value != v {
-					return defaultFloat64
-				}
-				return v
-			})
-		}
-	}
-
-	if m == matrix {
-		target = setSource
-		iterator = s.Enumerate()
-		source = m
-	} else if s == matrix {
-		target = setSource
-		iterator = m.Enumerate()
-		source = s
-	} else if IsSparseMatrix(s) {
-		iterator = s.Enumerate()
-		source = m
-	} else {
-		iterator = m.Enumerate()
-		source = s
-	}
-
-	for iterator.HasNext() {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			r, c, value := iterator.Next()
-			target(r, c, value)
-		}
-	}
+	EWiseMult(ctx, s, m, float64op.Multiplication, mask, nil, matrix)
 }
 
 // ElementWiseMatrixMultiply Element-wise multiplication on a matrix
@@ -164,96 +126,13 @@ func ElementWiseVectorMultiply(ctx context.Context, s, m Vector, mask GraphBLAS.
 	elementWiseMultiply(ctx, s, m, mask, vector)
 }
 
-// Add addition of a matrix by another matrix
+// Add addition of a matrix by another matrix over the union of their elements
 func Add(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
-	if s.Columns() != m.Columns() {
-		log.Panicf("Column mismatch %+v, %+v", s.Columns(), m.Columns())
-	}
-
-	if s.Rows() != m.Rows() {
-		log.Panicf("Row mismatch %+v, %+v", s.Rows(), m.Rows())
-	}
-
-	if mask == nil {
-		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
-	}
-
-	if mask.Rows() != matrix.Rows() {
-		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
-	}
-
-	if mask.Columns() != matrix.Columns() {
-		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
-	}
-
-	var iterator Enumerate
-	var source Matrix
-	if IsSparseMatrix(s) {
-		iterator = s.Enumerate()
-		source = m
-	} else {
-		iterator = m.Enumerate()
-		source = s
-	}
-
-	for iterator.HasNext() {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			r, c, v := iterator.Next()
-			value := source.At(r, c)
-			if !mask.Element(r, c) {
-				matrix.Set(r, c, value+v)
-			}
-		}
-	}
+	EWiseAdd(ctx, s, m, float64op.Addition, mask, nil, matrix)
 }
 
 func elementWiseAdd(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
-	if mask == nil {
-		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
-	}
-
-	if mask.Rows() != matrix.Rows() {
-		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
-	}
-
-	if mask.Columns() != matrix.Columns() {
-		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
-	}
-
-	if s != matrix {
-		for iterator := s.Enumerate(); iterator.HasNext(); {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				r, c, value := iterator.Next()
-				if value != defaultFloat64 {
-					if !mask.Element(r, c) {
-						matrix.Set(r, c, value)
-					}
-				}
-			}
-		}
-	}
-
-	if m != matrix {
-		for iterator := m.Enumerate(); iterator.HasNext(); {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				r, c, value := iterator.Next()
-				if value != defaultFloat64 {
-					if !mask.Element(r, c) {
-						matrix.Set(r, c, value)
-					}
-				}
-			}
-		}
-	}
+	EWiseAdd(ctx, s, m, float64op.Addition, mask, nil, matrix)
 }
 
 // ElementWiseMatrixAdd Element-wise addition on a matrix
@@ -278,41 +157,9 @@ func ElementWiseVectorAdd(ctx context.Context, s, m Vector, mask GraphBLAS.Mask,
 	elementWiseAdd(ctx, s, m, mask, vector)
 }
 
-// Subtract subtracts one matrix from another matrix
+// Subtract subtracts one matrix from another matrix over the union of their elements
 func Subtract(ctx context.Context, s, m Matrix, mask GraphBLAS.Mask, matrix Matrix) {
-	if s.Columns() != m.Columns() {
-		log.Panicf("Column mismatch %+v, %+v", s.Columns(), m.Columns())
-	}
-
-	if s.Rows() != m.Rows() {
-		log.Panicf("Row mismatch %+v, %+v", s.Rows(), m.Rows())
-	}
-
-	if mask == nil {
-		mask = GraphBLAS.NewEmptyMask(matrix.Rows(), matrix.Columns())
-	}
-
-	if mask.Rows() != matrix.Rows() {
-		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), matrix.Rows())
-	}
-
-	if mask.Columns() != matrix.Columns() {
-		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), matrix.Columns())
-	}
-
-	for iterator := s.Enumerate(); iterator.HasNext(); {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			r, c, value := iterator.Next()
-			if !mask.Element(r, c) {
-				matrix.Update(r, c, func(v float64) float64 {
-					return value - v
-				})
-			}
-		}
-	}
+	EWiseUnion(ctx, s, 0, m, 0, float64op.Subtraction, mask, nil, matrix)
 }
 
 // Apply modifies edge weights by the UnaryOperator
@@ -523,20 +370,98 @@ func ReduceMatrixToVector(ctx context.Context, s Matrix) Vector {
 	return ReduceMatrixToVectorWithMonoID(ctx, s, defaultMonoIDMaximum, nil)
 }
 
-// ReduceMatrixToVectorWithMonoID perform's a reduction on the Matrix
+// ReduceMatrixToVectorWithMonoID perform's a reduction of each column of the Matrix, masked elements are left out of the reduction
 // monoid used in the element-wise reduction operation
 func ReduceMatrixToVectorWithMonoID(ctx context.Context, s Matrix, monoID float64op.MonoIDFloat64, mask GraphBLAS.Mask) Vector {
+	if mask == nil {
+		mask = GraphBLAS.NewEmptyMask(s.Rows(), s.Columns())
+	}
+
+	if mask.Rows() != s.Rows() {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), s.Rows())
+	}
+
+	if mask.Columns() != s.Columns() {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), s.Columns())
+	}
 
 	vector := NewDenseVector(s.Columns())
-	for c := 0; c < s.Columns(); c++ {
-		v := s.ColumnsAt(c)
-		scaler := ReduceVectorToScalarWithMonoID(ctx, v, monoID, mask)
-		vector.SetVec(c, scaler)
+	for c := range vector.values {
+		vector.values[c] = monoID.Zero()
 	}
 
+	reduce(ctx, s, monoID, mask, true, func(c int, value float64) {
+		vector.SetVec(c, value)
+	})
+
 	return vector
 }
 
+// ReduceToVector perform's a reduction of each row of the Matrix, with the descriptor TransposeFirst each column is reduced,
+// rows or columns without elements leave no element in the output
+// monoid used in the element-wise reduction operation
+//  w<m> ⊙= [⊕ⱼ A(:, j)]
+func ReduceToVector(ctx context.Context, s Matrix, monoID float64op.MonoIDFloat64, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, descriptor *GraphBLAS.Descriptor, vector Vector) {
+	columns := descriptor.Transposed()
+
+	length := s.Rows()
+	if columns {
+		length = s.Columns()
+	}
+
+	if vector.Length() != length {
+		log.Panicf("Can not reduce found length mismatch %+v, %+v", length, vector.Length())
+	}
+
+	t := NewSparseVector(length)
+	reduce(ctx, s, monoID, GraphBLAS.NewEmptyMask(s.Rows(), s.Columns()), columns, func(i int, value float64) {
+		t.SetVec(i, value)
+	})
+
+	accumulate(ctx, t, mask, accumulator, vector)
+}
+
+// reduce folds the unmasked elements of each row, or each column, of the matrix calling set once for each non empty one
+func reduce(ctx context.Context, s Matrix, monoID float64op.MonoIDFloat64, mask GraphBLAS.Mask, columns bool, set func(int, float64)) {
+	length := s.Rows()
+	if columns {
+		length = s.Columns()
+	}
+
+	values := make([]float64, length)
+	present := make([]bool, length)
+
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, value := iterator.Next()
+			if mask.Element(r, c) {
+				continue
+			}
+
+			i := r
+			if columns {
+				i = c
+			}
+
+			if present[i] {
+				values[i] = monoID.Apply(values[i], value)
+			} else {
+				values[i] = value
+				present[i] = true
+			}
+		}
+	}
+
+	for i := range values {
+		if present[i] {
+			set(i, values[i])
+		}
+	}
+}
+
 // ReduceVectorToScalar perform's a reduction on the Matrix
 func ReduceVectorToScalar(ctx context.Context, s Vector, mask GraphBLAS.Mask) float64 {
 	return ReduceMatrixToScalar(ctx, s, mask)
@@ -593,20 +518,3 @@ func ReduceMatrixToScalarWithMonoID(ctx context.Context, s Matrix, monoID float6
 	return <-out
 
 }
-
-// AssignConstantVector the contents of a subset of a vector
-// func AssignConstantVector(w, mask Vector, val float64, nindices int) {
-
-// 	// if u.Length() != nindices {
-// 	// 	log.Panicf("The number of values in indices array. Must be equal to Length of u %+v", u.Length())
-// 	// }
-
-// 	for iterator := mask.Enumerate(); iterator.HasNext(); {
-// 		r, _, _ := iterator.Next()
-// 		if r < nindices {
-// 			w.SetVec(r, val)
-// 		} else {
-// 			break
-// 		}
-// 	}
-// }
@@ -6,9 +6,15 @@
 package doubleprecision_test
 
 import (
-	"github.com/rossmerr/graphblas/doubleprecision"
+	"math"
+	"math/rand"
 	"testing"
 
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+	"github.com/rossmerr/graphblas/doubleprecision"
+	float64UnaryOp "github.com/rossmerr/graphblas/unaryop/float64op"
+
 	"golang.org/x/net/context"
 )
 
@@ -242,7 +248,7 @@ func TestMatrix_ElementWiseMatrixMultiply(t *testing.T) {
 func TestMatrix_ElementWiseVectorMultiply(t *testing.T) {
 	vector := doubleprecision.NewDenseVectorFromArray([]float64{0, 1, 0, 0, 0, 1, 0})
 
-	want := doubleprecision.NewDenseVectorFromArray([]float64{0, 1, 0, 0, 0, 0, 0})
+	want := doubleprecision.NewSparseVectorFromArray([]float64{0, 1, 0, 0, 0, 0, 0})
 
 	setup := []float64{0, 1, 0, 0, 0, 0, 1}
 
@@ -273,7 +279,7 @@ func TestMatrix_ElementWiseVectorMultiply(t *testing.T) {
 func TestMatrix_ElementWiseMatrixAdd(t *testing.T) {
 	array := [][]float64{
 		[]float64{0, 1, 0, 1, 0, 0, 0},
-		[]float64{0, 0, 0, 0, 1, 0, 1},
+		[]float64{0, 0, 0, 0, 1, 0, 2},
 		[]float64{0, 0, 0, 0, 0, 0, 0},
 		[]float64{1, 0, 0, 0, 0, 0, 0},
 		[]float64{0, 0, 0, 0, 0, 0, 0},
@@ -342,7 +348,7 @@ func TestMatrix_ElementWiseMatrixAdd(t *testing.T) {
 func TestMatrix_ElementWiseVectorAdd(t *testing.T) {
 	vector := doubleprecision.NewDenseVectorFromArray([]float64{0, 1, 0, 0, 0, 1, 0})
 
-	want := doubleprecision.NewDenseVectorFromArray([]float64{0, 1, 0, 0, 0, 1, 1})
+	want := doubleprecision.NewDenseVectorFromArray([]float64{0, 2, 0, 0, 0, 1, 1})
 
 	setup := []float64{0, 1, 0, 0, 0, 0, 1}
 
@@ -560,3 +566,807 @@ func TestMatrix_ReduceVectorToScalar(t *testing.T) {
 		})
 	}
 }
+
+func TestTriangularSolve(t *testing.T) {
+	setup := func(m doubleprecision.Matrix) {
+		m.Set(0, 0, 2)
+		m.Set(1, 0, 1)
+		m.Set(1, 1, 4)
+		m.Set(2, 0, 3)
+		m.Set(2, 1, 2)
+		m.Set(2, 2, 5)
+	}
+
+	tests := []struct {
+		name     string
+		s        doubleprecision.Matrix
+		triangle doubleprecision.Triangle
+	}{
+		{name: "DenseMatrix Lower", s: doubleprecision.NewDenseMatrix(3, 3), triangle: doubleprecision.Lower},
+		{name: "CSCMatrix Lower", s: doubleprecision.NewCSCMatrix(3, 3), triangle: doubleprecision.Lower},
+		{name: "CSRMatrix Lower", s: doubleprecision.NewCSRMatrix(3, 3), triangle: doubleprecision.Lower},
+		{name: "DenseMatrix Upper", s: doubleprecision.NewDenseMatrix(3, 3), triangle: doubleprecision.Upper},
+		{name: "CSCMatrix Upper", s: doubleprecision.NewCSCMatrix(3, 3), triangle: doubleprecision.Upper},
+		{name: "CSRMatrix Upper", s: doubleprecision.NewCSRMatrix(3, 3), triangle: doubleprecision.Upper},
+	}
+
+	for _, tt := range tests {
+		setup(tt.s)
+		a := tt.s
+		if tt.triangle == doubleprecision.Upper {
+			a = tt.s.Transpose()
+		}
+
+		want := doubleprecision.NewDenseMatrixFromArray([][]float64{{1, 2}, {-1, 0}, {3, 1}})
+		b := doubleprecision.NewDenseMatrix(3, 2)
+		doubleprecision.MatrixMatrixMultiply(context.Background(), a, want, nil, b)
+
+		for _, solve := range []func(context.Context, doubleprecision.Matrix, doubleprecision.Triangle, bool, doubleprecision.Matrix, doubleprecision.Matrix) error{
+			doubleprecision.TriangularSolve,
+			doubleprecision.TriangularSolveParallel,
+		} {
+			got := doubleprecision.NewDenseMatrix(3, 2)
+			if err := solve(context.Background(), a, tt.triangle, false, b, got); err != nil {
+				t.Errorf("%+v TriangularSolve error = %+v", tt.name, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("%+v TriangularSolve = %+v, want %+v", tt.name, got, want)
+			}
+		}
+	}
+}
+
+func TestTriangularSolve_Unit(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{7, 0, 0},
+		{2, 7, 0},
+		{0, 3, 7},
+	})
+	b := doubleprecision.NewDenseVectorFromArray([]float64{1, 4, 15})
+	want := doubleprecision.NewDenseVectorFromArray([]float64{1, 2, 9})
+
+	got := doubleprecision.NewDenseVector(3)
+	if err := doubleprecision.TriangularSolve(context.Background(), a, doubleprecision.Lower, true, b, got); err != nil {
+		t.Errorf("TriangularSolve error = %+v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("TriangularSolve = %+v, want %+v", got, want)
+	}
+}
+
+func TestTriangularSolve_Sparse(t *testing.T) {
+	array := [][]float64{
+		{2, 0, 0, 0},
+		{1, 2, 0, 0},
+		{0, 0, 2, 0},
+		{0, 0, 1, 2},
+	}
+	b := doubleprecision.NewCSRMatrix(4, 1)
+	b.Set(2, 0, 4)
+
+	for _, a := range []doubleprecision.Matrix{
+		doubleprecision.NewCSRMatrixFromArray(array),
+		doubleprecision.NewCSCMatrixFromArray(array),
+	} {
+		for _, solve := range []func(context.Context, doubleprecision.Matrix, doubleprecision.Triangle, bool, doubleprecision.Matrix, doubleprecision.Matrix) error{
+			doubleprecision.TriangularSolve,
+			doubleprecision.TriangularSolveParallel,
+		} {
+			// only rows 2 and 3 are reached from b, an element outside the reach is removed
+			got := doubleprecision.NewCSRMatrix(4, 1)
+			got.Set(0, 0, 7)
+			if err := solve(context.Background(), a, doubleprecision.Lower, false, b, got); err != nil {
+				t.Errorf("TriangularSolve error = %+v", err)
+			}
+			if got.NVals() != 2 || got.At(2, 0) != 2 || got.At(3, 0) != -1 {
+				t.Errorf("TriangularSolve = %+v, want %+v stored elements", got, 2)
+			}
+		}
+	}
+}
+
+func TestTriangularSolve_ZeroDiagonal(t *testing.T) {
+	a := doubleprecision.NewCSCMatrixFromArray([][]float64{
+		{1, 0},
+		{1, 0},
+	})
+	b := doubleprecision.NewDenseVectorFromArray([]float64{1, 1})
+
+	for _, solve := range []func(context.Context, doubleprecision.Matrix, doubleprecision.Triangle, bool, doubleprecision.Matrix, doubleprecision.Matrix) error{
+		doubleprecision.TriangularSolve,
+		doubleprecision.TriangularSolveParallel,
+	} {
+		if err := solve(context.Background(), a, doubleprecision.Lower, false, b, doubleprecision.NewDenseVector(2)); err != doubleprecision.ErrZeroDiagonal {
+			t.Errorf("TriangularSolve error = %+v, want %+v", err, doubleprecision.ErrZeroDiagonal)
+		}
+	}
+}
+
+func TestMatrixVectorMultiply_SlicedEll(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	csr := doubleprecision.NewCSRMatrix(37, 23)
+	for i := 0; i < 150; i++ {
+		csr.Set(random.Intn(37), random.Intn(23), random.Float64())
+	}
+	// a stored zero still makes the row stored
+	csr.Set(36, 0, 0)
+
+	dense := doubleprecision.NewDenseVector(23)
+	sparse := doubleprecision.NewSparseVector(23)
+	for i := 0; i < 23; i++ {
+		dense.SetVec(i, random.Float64())
+		if i%3 == 0 {
+			sparse.SetVec(i, dense.AtVec(i))
+		}
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "ELLMatrix",
+			s:    doubleprecision.NewELLMatrixFromMatrix(csr),
+		},
+		{
+			name: "SELLMatrix",
+			s:    doubleprecision.NewSELLMatrixFromMatrix(csr, 4, 16),
+		},
+	}
+	for _, tt := range tests {
+		for _, vector := range []doubleprecision.Vector{dense, sparse} {
+			want := doubleprecision.NewSparseVector(37)
+			doubleprecision.MatrixVectorMultiply(context.Background(), csr, vector, nil, want)
+
+			got := doubleprecision.NewSparseVector(37)
+			doubleprecision.MatrixVectorMultiply(context.Background(), tt.s, vector, nil, got)
+
+			if got.NVals() != want.NVals() {
+				t.Errorf("%+v MatrixVectorMultiply NVals = %+v, want %+v", tt.name, got.NVals(), want.NVals())
+			}
+
+			for i := 0; i < 37; i++ {
+				if math.Abs(got.AtVec(i)-want.AtVec(i)) > 1e-12 {
+					t.Errorf("%+v MatrixVectorMultiply = %+v, want %+v", tt.name, got.AtVec(i), want.AtVec(i))
+				}
+			}
+		}
+	}
+}
+
+func TestSELLMatrix_Set(t *testing.T) {
+	want := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 0, 0, 2},
+		{0, 0, 0, 0},
+		{3, 4, 5, 0},
+		{0, 6, 0, 0},
+		{0, 0, 0, 7},
+	})
+
+	s := doubleprecision.NewSELLMatrixFromMatrix(want, 2, 4)
+	if s.NotEqual(want) {
+		t.Errorf("SELLMatrix = %+v, want %+v", s, want)
+	}
+
+	// rows grow past the width of their slice
+	s.Set(1, 0, 8)
+	s.Set(1, 1, 9)
+	s.Set(1, 3, 10)
+	s.Set(4, 0, 0)
+	want.Set(1, 0, 8)
+	want.Set(1, 1, 9)
+	want.Set(1, 3, 10)
+	want.Set(4, 0, 0)
+
+	if s.NotEqual(want) {
+		t.Errorf("SELLMatrix Set = %+v, want %+v", s, want)
+	}
+
+	s.Remove(2, 1)
+	want.Remove(2, 1)
+	if s.NotEqual(want) || s.Has(2, 1) {
+		t.Errorf("SELLMatrix Remove = %+v, want %+v", s, want)
+	}
+
+	if got := s.Transpose(); got.NotEqual(want.Transpose()) {
+		t.Errorf("SELLMatrix Transpose = %+v, want %+v", got, want.Transpose())
+	}
+}
+
+func TestExtract(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2, 0, 4},
+		{0, 6, 7, 0},
+		{9, 0, 11, 12},
+	})
+
+	tests := []struct {
+		name        string
+		rows        doubleprecision.Index
+		cols        doubleprecision.Index
+		mask        doubleprecision.Matrix
+		accumulator float64op.BinaryOpFloat64
+		matrix      doubleprecision.Matrix
+		want        [][]float64
+	}{
+		{
+			name:   "Indices",
+			rows:   doubleprecision.List{2, 0},
+			cols:   doubleprecision.Stride(3, -1, -2),
+			matrix: doubleprecision.NewCSRMatrix(2, 2),
+			want: [][]float64{
+				{12, 0},
+				{4, 2},
+			},
+		},
+		{
+			name:   "All",
+			rows:   doubleprecision.All,
+			cols:   doubleprecision.List{1, 1},
+			matrix: doubleprecision.NewCSRMatrix(3, 2),
+			want: [][]float64{
+				{2, 2},
+				{6, 6},
+				{0, 0},
+			},
+		},
+		{
+			name:        "Accumulator",
+			rows:        doubleprecision.Range(1, 3),
+			cols:        doubleprecision.Range(0, 2),
+			accumulator: float64op.Addition,
+			matrix: doubleprecision.NewCSRMatrixFromArray([][]float64{
+				{1, 1},
+				{1, 1},
+			}),
+			want: [][]float64{
+				{1, 7},
+				{10, 1},
+			},
+		},
+		{
+			name: "Mask",
+			rows: doubleprecision.Range(1, 3),
+			cols: doubleprecision.Range(0, 2),
+			mask: doubleprecision.NewCSRMatrixFromArray([][]float64{
+				{0, 1},
+				{0, 0},
+			}),
+			matrix: doubleprecision.NewCSRMatrixFromArray([][]float64{
+				{1, 1},
+				{1, 1},
+			}),
+			want: [][]float64{
+				{0, 1},
+				{9, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doubleprecision.Extract(context.Background(), a, tt.rows, tt.cols, tt.mask, tt.accumulator, tt.matrix)
+
+			want := doubleprecision.NewCSRMatrixFromArray(tt.want)
+			if tt.matrix.NotEqual(want) || want.NotEqual(tt.matrix) {
+				t.Errorf("%+v Extract = %+v, want %+v", tt.name, tt.matrix, want)
+			}
+		})
+	}
+}
+
+func TestExtractVector(t *testing.T) {
+	u := doubleprecision.NewSparseVectorFromArray([]float64{1, 0, 3, 4})
+	vector := doubleprecision.NewSparseVector(3)
+
+	doubleprecision.ExtractVector(context.Background(), u, doubleprecision.List{3, 2, 3}, nil, nil, vector)
+
+	want := doubleprecision.NewSparseVectorFromArray([]float64{4, 3, 4})
+	if vector.NotEqual(want) {
+		t.Errorf("ExtractVector = %+v, want %+v", vector, want)
+	}
+}
+
+func TestIndex_Empty(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+
+	var ids doubleprecision.List
+
+	matrix := doubleprecision.NewCSRMatrix(0, 2)
+	doubleprecision.Extract(context.Background(), a, ids, doubleprecision.All, nil, nil, matrix)
+	if matrix.Values() != 0 {
+		t.Errorf("Extract Values = %+v, want %+v", matrix.Values(), 0)
+	}
+
+	// an empty selection assigns nothing
+	want := a.Copy()
+	doubleprecision.AssignConstant(context.Background(), 9, ids, doubleprecision.All, nil, nil, a)
+	if a.NotEqual(want) {
+		t.Errorf("AssignConstant = %+v, want %+v", a, want)
+	}
+
+	vector := doubleprecision.NewSparseVectorFromArray([]float64{1, 2})
+	doubleprecision.AssignConstantVector(context.Background(), 9, doubleprecision.List{}, nil, nil, vector)
+	if got := vector.AtVec(1); got != 2 {
+		t.Errorf("AssignConstantVector = %+v, want %+v", got, 2)
+	}
+}
+
+func TestAssign(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 0},
+		{0, 2},
+	})
+
+	setup := func() doubleprecision.Matrix {
+		return doubleprecision.NewCSRMatrixFromArray([][]float64{
+			{5, 5, 5},
+			{5, 5, 5},
+			{5, 5, 5},
+		})
+	}
+
+	tests := []struct {
+		name   string
+		assign func(matrix doubleprecision.Matrix)
+		want   [][]float64
+	}{
+		{
+			name: "Assign",
+			assign: func(matrix doubleprecision.Matrix) {
+				doubleprecision.Assign(context.Background(), a, doubleprecision.List{2, 0}, doubleprecision.List{0, 2}, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{0, 5, 2},
+				{5, 5, 5},
+				{1, 5, 0},
+			},
+		},
+		{
+			name: "Assign accumulator",
+			assign: func(matrix doubleprecision.Matrix) {
+				doubleprecision.Assign(context.Background(), a, doubleprecision.List{2, 0}, doubleprecision.List{0, 2}, nil, float64op.Addition, matrix)
+			},
+			want: [][]float64{
+				{5, 5, 7},
+				{5, 5, 5},
+				{6, 5, 5},
+			},
+		},
+		{
+			name: "Assign mask",
+			assign: func(matrix doubleprecision.Matrix) {
+				mask := doubleprecision.NewCSRMatrix(3, 3)
+				mask.Set(0, 2, 1)
+				doubleprecision.Assign(context.Background(), a, doubleprecision.List{2, 0}, doubleprecision.List{0, 2}, mask, nil, matrix)
+			},
+			want: [][]float64{
+				{0, 5, 5},
+				{5, 5, 5},
+				{1, 5, 0},
+			},
+		},
+		{
+			name: "SubAssign mask",
+			assign: func(matrix doubleprecision.Matrix) {
+				mask := doubleprecision.NewCSRMatrix(2, 2)
+				mask.Set(0, 0, 1)
+				doubleprecision.SubAssign(context.Background(), a, doubleprecision.List{2, 0}, doubleprecision.List{0, 2}, mask, nil, matrix)
+			},
+			want: [][]float64{
+				{0, 5, 2},
+				{5, 5, 5},
+				{5, 5, 0},
+			},
+		},
+		{
+			name: "AssignConstant",
+			assign: func(matrix doubleprecision.Matrix) {
+				doubleprecision.AssignConstant(context.Background(), 3, doubleprecision.Range(1, 3), doubleprecision.All, nil, float64op.Multiplication, matrix)
+			},
+			want: [][]float64{
+				{5, 5, 5},
+				{15, 15, 15},
+				{15, 15, 15},
+			},
+		},
+		{
+			name: "SubAssignConstant",
+			assign: func(matrix doubleprecision.Matrix) {
+				// the mask is the size of the region, the element 0, 0 of the region is matrix 2, 0
+				mask := doubleprecision.NewCSRMatrixFromArray([][]float64{
+					{1, 0},
+					{0, 0},
+				})
+				doubleprecision.SubAssignConstant(context.Background(), 7, doubleprecision.List{2, 0}, doubleprecision.List{0, 2}, mask, nil, matrix)
+			},
+			want: [][]float64{
+				{7, 5, 7},
+				{5, 5, 5},
+				{5, 5, 7},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := setup()
+			tt.assign(matrix)
+
+			want := doubleprecision.NewCSRMatrixFromArray(tt.want)
+			if matrix.NotEqual(want) || want.NotEqual(matrix) {
+				t.Errorf("%+v = %+v, want %+v", tt.name, matrix, want)
+			}
+		})
+	}
+}
+
+func TestAssignConstantVector(t *testing.T) {
+	levels := doubleprecision.NewSparseVector(5)
+	levels.SetVec(0, 1)
+
+	frontier := doubleprecision.NewSparseVectorFromArray([]float64{0, 1, 0, 1, 0})
+
+	// levels<frontier> = 2
+	doubleprecision.AssignConstantVector(context.Background(), 2, doubleprecision.All, GraphBLAS.NewComplementMask(frontier), float64op.SecondArgument, levels)
+
+	want := doubleprecision.NewSparseVectorFromArray([]float64{1, 2, 0, 2, 0})
+	if levels.NotEqual(want) || want.NotEqual(levels) {
+		t.Errorf("AssignConstantVector = %+v, want %+v", levels, want)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+
+	tests := []struct {
+		name string
+		op   float64UnaryOp.IndexUnaryOpFloat64
+		want [][]float64
+	}{
+		{
+			name: "Tril",
+			op:   float64UnaryOp.Tril(-1),
+			want: [][]float64{
+				{0, 0, 0},
+				{4, 0, 0},
+				{7, 8, 0},
+			},
+		},
+		{
+			name: "Diag",
+			op:   float64UnaryOp.Diag(0),
+			want: [][]float64{
+				{1, 0, 0},
+				{0, 5, 0},
+				{0, 0, 9},
+			},
+		},
+		{
+			name: "ValueGreaterThan",
+			op:   float64UnaryOp.ValueGreaterThan(6),
+			want: [][]float64{
+				{0, 0, 0},
+				{0, 0, 0},
+				{7, 8, 9},
+			},
+		},
+		{
+			name: "IndexUnaryOp",
+			op: float64UnaryOp.NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+				return (r+c)%2 == 0 && value < 5
+			}),
+			want: [][]float64{
+				{1, 0, 3},
+				{0, 0, 0},
+				{0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := doubleprecision.NewCSRMatrix(3, 3)
+			doubleprecision.Select(context.Background(), a, tt.op, nil, nil, matrix)
+
+			want := doubleprecision.NewCSRMatrixFromArray(tt.want)
+			if matrix.NotEqual(want) || want.NotEqual(matrix) {
+				t.Errorf("%+v Select = %+v, want %+v", tt.name, matrix, want)
+			}
+		})
+	}
+}
+
+func TestSelect_InPlace(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+
+	doubleprecision.Select(context.Background(), a, float64UnaryOp.Triu(0), nil, nil, a)
+
+	want := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2},
+		{0, 4},
+	})
+	if a.NotEqual(want) || a.Has(1, 0) {
+		t.Errorf("Select = %+v, want %+v", a, want)
+	}
+}
+
+func TestKronecker(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2},
+		{0, 3},
+	})
+	b := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{0, 5, 1},
+		{6, 0, 0},
+	})
+
+	want := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{0, 5, 1, 0, 10, 2},
+		{6, 0, 0, 12, 0, 0},
+		{0, 0, 0, 0, 15, 3},
+		{0, 0, 0, 18, 0, 0},
+	})
+
+	tests := []struct {
+		name   string
+		matrix doubleprecision.Matrix
+	}{
+		{
+			name:   "CSRMatrix",
+			matrix: doubleprecision.NewCSRMatrix(4, 6),
+		},
+		{
+			name:   "CSCMatrix",
+			matrix: doubleprecision.NewCSCMatrix(4, 6),
+		},
+		{
+			name:   "DenseMatrix",
+			matrix: doubleprecision.NewDenseMatrix(4, 6),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doubleprecision.Kronecker(context.Background(), a, b, float64op.Multiplication, nil, tt.matrix)
+			if tt.matrix.NotEqual(want) || want.NotEqual(tt.matrix) {
+				t.Errorf("%+v Kronecker = %+v, want %+v", tt.name, tt.matrix, want)
+			}
+		})
+	}
+
+	matrix := doubleprecision.NewCSRMatrix(4, 6)
+	doubleprecision.Kronecker(context.Background(), a, b, float64op.Multiplication, nil, matrix)
+	if matrix.NVals() != 9 {
+		t.Errorf("Kronecker NVals = %+v, want %+v", matrix.NVals(), 9)
+	}
+
+	mask := doubleprecision.NewCSRMatrix(4, 6)
+	mask.Set(0, 1, 1)
+	doubleprecision.Kronecker(context.Background(), a, b, float64op.Addition, mask, matrix)
+	if matrix.At(0, 1) != 5 || matrix.At(0, 4) != 7 {
+		t.Errorf("Kronecker mask = %+v, want %+v", matrix.At(0, 4), 7)
+	}
+}
+
+func TestEWise(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2, 0},
+		{0, 3, 4},
+	})
+	b := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{5, 0, 6},
+		{0, 7, 8},
+	})
+
+	tests := []struct {
+		name  string
+		ewise func(matrix doubleprecision.Matrix)
+		want  [][]float64
+		nvals int
+	}{
+		{
+			name: "EWiseMult",
+			ewise: func(matrix doubleprecision.Matrix) {
+				doubleprecision.EWiseMult(context.Background(), a, b, float64op.Subtraction, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{-4, 0, 0},
+				{0, -4, -4},
+			},
+			nvals: 3,
+		},
+		{
+			name: "EWiseMult BinaryOpFloat64ToBool",
+			ewise: func(matrix doubleprecision.Matrix) {
+				doubleprecision.EWiseMult(context.Background(), a, b, float64op.LessThan, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{1, 0, 0},
+				{0, 1, 1},
+			},
+			nvals: 3,
+		},
+		{
+			name: "EWiseAdd",
+			ewise: func(matrix doubleprecision.Matrix) {
+				doubleprecision.EWiseAdd(context.Background(), a, b, float64op.Subtraction, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{-4, 2, 6},
+				{0, -4, -4},
+			},
+			nvals: 5,
+		},
+		{
+			name: "EWiseUnion",
+			ewise: func(matrix doubleprecision.Matrix) {
+				doubleprecision.EWiseUnion(context.Background(), a, 10, b, 20, float64op.Subtraction, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{-4, -18, 4},
+				{0, -4, -4},
+			},
+			nvals: 5,
+		},
+		{
+			name: "EWiseAdd accumulator",
+			ewise: func(matrix doubleprecision.Matrix) {
+				matrix.Set(1, 0, 1)
+				matrix.Set(1, 1, 1)
+				doubleprecision.EWiseAdd(context.Background(), a, b, float64op.Addition, nil, float64op.Addition, matrix)
+			},
+			want: [][]float64{
+				{6, 2, 6},
+				{1, 11, 12},
+			},
+			nvals: 6,
+		},
+		{
+			name: "EWiseAdd replace",
+			ewise: func(matrix doubleprecision.Matrix) {
+				matrix.Set(1, 0, 1)
+				doubleprecision.EWiseAdd(context.Background(), a, b, float64op.Addition, nil, nil, matrix)
+			},
+			want: [][]float64{
+				{6, 2, 6},
+				{0, 10, 12},
+			},
+			nvals: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := doubleprecision.NewCSRMatrix(2, 3)
+			tt.ewise(matrix)
+
+			want := doubleprecision.NewCSRMatrixFromArray(tt.want)
+			if matrix.NotEqual(want) || want.NotEqual(matrix) {
+				t.Errorf("%+v = %+v, want %+v", tt.name, matrix, want)
+			}
+
+			if matrix.NVals() != tt.nvals {
+				t.Errorf("%+v NVals = %+v, want %+v", tt.name, matrix.NVals(), tt.nvals)
+			}
+		})
+	}
+}
+
+func TestMatrix_Add_Union(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 0},
+		{0, 2},
+	})
+	b := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{3, 4},
+		{0, 0},
+	})
+
+	want := doubleprecision.NewDenseMatrixFromArray([][]float64{
+		{4, 4},
+		{0, 2},
+	})
+	if got := a.Add(b); got.NotEqual(want) || want.NotEqual(got) {
+		t.Errorf("Add = %+v, want %+v", got, want)
+	}
+
+	want = doubleprecision.NewDenseMatrixFromArray([][]float64{
+		{-2, -4},
+		{0, 2},
+	})
+	if got := a.Subtract(b); got.NotEqual(want) || want.NotEqual(got) {
+		t.Errorf("Subtract = %+v, want %+v", got, want)
+	}
+}
+
+func TestReduceToVector(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2, 0},
+		{0, 0, 0},
+		{3, 0, 4},
+	})
+
+	addition := float64op.NewMonoIDFloat64(0, float64op.Addition)
+
+	tests := []struct {
+		name   string
+		reduce func(vector doubleprecision.Vector)
+		want   []float64
+		nvals  int
+	}{
+		{
+			name: "Rows",
+			reduce: func(vector doubleprecision.Vector) {
+				doubleprecision.ReduceToVector(context.Background(), a, addition, nil, nil, nil, vector)
+			},
+			want:  []float64{3, 0, 7},
+			nvals: 2,
+		},
+		{
+			name: "Columns",
+			reduce: func(vector doubleprecision.Vector) {
+				doubleprecision.ReduceToVector(context.Background(), a, addition, nil, nil, &GraphBLAS.Descriptor{TransposeFirst: true}, vector)
+			},
+			want:  []float64{4, 2, 4},
+			nvals: 3,
+		},
+		{
+			name: "Mask",
+			reduce: func(vector doubleprecision.Vector) {
+				vector.SetVec(0, 10)
+				mask := doubleprecision.NewSparseVectorFromArray([]float64{1, 0, 0})
+				doubleprecision.ReduceToVector(context.Background(), a, addition, mask, nil, nil, vector)
+			},
+			want:  []float64{10, 0, 7},
+			nvals: 2,
+		},
+		{
+			name: "Accumulator",
+			reduce: func(vector doubleprecision.Vector) {
+				vector.SetVec(0, 10)
+				vector.SetVec(1, 5)
+				doubleprecision.ReduceToVector(context.Background(), a, addition, nil, float64op.Minimum, nil, vector)
+			},
+			want:  []float64{3, 5, 7},
+			nvals: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vector := doubleprecision.NewSparseVector(3)
+			tt.reduce(vector)
+
+			want := doubleprecision.NewSparseVectorFromArray(tt.want)
+			if vector.NotEqual(want) || want.NotEqual(vector) {
+				t.Errorf("%+v ReduceToVector = %+v, want %+v", tt.name, vector, want)
+			}
+
+			if vector.NVals() != tt.nvals {
+				t.Errorf("%+v NVals = %+v, want %+v", tt.name, vector.NVals(), tt.nvals)
+			}
+		})
+	}
+}
+
+func TestReduceMatrixToVectorWithMonoID_Mask(t *testing.T) {
+	a := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+
+	mask := doubleprecision.NewCSRMatrix(2, 2)
+	mask.Set(1, 0, 1)
+
+	got := doubleprecision.ReduceMatrixToVectorWithMonoID(context.Background(), a, float64op.NewMonoIDFloat64(0, float64op.Addition), mask)
+
+	want := doubleprecision.NewDenseVectorFromArray([]float64{1, 6})
+	if got.NotEqual(want) {
+		t.Errorf("ReduceMatrixToVectorWithMonoID = %+v, want %+v", got, want)
+	}
+}
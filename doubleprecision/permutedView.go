@@ -0,0 +1,236 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+)
+
+// PermutedView a read only view of a matrix with its rows and columns permuted,
+// elements are read from the matrix without copying
+//  view(r, c) = matrix(p[r], q[c])
+type PermutedView struct {
+	matrix Matrix
+	p      []int // row permutation
+	q      []int // column permutation
+	pInv   []int // inverse row permutation
+	qInv   []int // inverse column permutation
+}
+
+// NewPermutedView returns a PermutedView of the matrix, a nil permutation leaves the rows or columns in order
+func NewPermutedView(m Matrix, p, q []int) *PermutedView {
+	return &PermutedView{
+		matrix: m,
+		p:      p,
+		q:      q,
+		pInv:   invertPermutation(p, m.Rows()),
+		qInv:   invertPermutation(q, m.Columns()),
+	}
+}
+
+// invertPermutation the inverse of the permutation, panics if it is not a permutation of 0 to n
+func invertPermutation(p []int, n int) []int {
+	if p == nil {
+		return nil
+	}
+
+	if len(p) != n {
+		log.Panicf("Can not permute %+v with a permutation of length %+v", n, len(p))
+	}
+
+	inverse := make([]int, n)
+	for i := range inverse {
+		inverse[i] = -1
+	}
+
+	for i, k := range p {
+		if k < 0 || k >= n || inverse[k] != -1 {
+			log.Panicf("Permutation '%+v' is invalid", p)
+		}
+		inverse[k] = i
+	}
+	return inverse
+}
+
+func permute(p []int, i int) int {
+	if p == nil {
+		return i
+	}
+	return p[i]
+}
+
+// wrapped the viewed matrix
+func (s *PermutedView) wrapped() Matrix {
+	return s.matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *PermutedView) Columns() int {
+	return s.matrix.Columns()
+}
+
+// Rows the number of rows of the matrix
+func (s *PermutedView) Rows() int {
+	return s.matrix.Rows()
+}
+
+// Update a view is read only and panics
+func (s *PermutedView) Update(r, c int, f func(float64) float64) {
+	readOnly(r, c)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *PermutedView) At(r, c int) float64 {
+	return s.matrix.At(permute(s.p, r), permute(s.q, c))
+}
+
+// Has the element at r-th, c-th is stored
+func (s *PermutedView) Has(r, c int) bool {
+	if r < 0 || r >= s.Rows() || c < 0 || c >= s.Columns() {
+		return false
+	}
+	return s.matrix.Has(permute(s.p, r), permute(s.q, c))
+}
+
+// Remove a view is read only and panics
+func (s *PermutedView) Remove(r, c int) {
+	readOnly(r, c)
+}
+
+// Set a view is read only and panics
+func (s *PermutedView) Set(r, c int, value float64) {
+	readOnly(r, c)
+}
+
+// ColumnsAt return the columns at c-th
+func (s *PermutedView) ColumnsAt(c int) Vector {
+	columns := NewSparseVector(s.Rows())
+	for iterator := s.matrix.ColumnsAt(permute(s.q, c)).Enumerate(); iterator.HasNext(); {
+		r, _, value := iterator.Next()
+		columns.SetVec(permute(s.pInv, r), value)
+	}
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *PermutedView) RowsAt(r int) Vector {
+	rows := NewSparseVector(s.Columns())
+	for iterator := s.matrix.RowsAt(permute(s.p, r)).Enumerate(); iterator.HasNext(); {
+		c, _, value := iterator.Next()
+		rows.SetVec(permute(s.qInv, c), value)
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *PermutedView) RowsAtToArray(r int) []float64 {
+	rows := make([]float64, s.Columns())
+	for iterator := s.matrix.RowsAt(permute(s.p, r)).Enumerate(); iterator.HasNext(); {
+		c, _, value := iterator.Next()
+		rows[permute(s.qInv, c)] = value
+	}
+	return rows
+}
+
+// Copy copies the elements of the view into a new matrix
+func (s *PermutedView) Copy() Matrix {
+	return materialise(s, s.matrix)
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *PermutedView) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *PermutedView) Multiply(m Matrix) Matrix {
+	matrix := newMaterialised(s.Rows(), m.Columns(), s.matrix)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *PermutedView) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *PermutedView) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *PermutedView) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *PermutedView) Transpose() Matrix {
+	return s.Copy().Transpose()
+}
+
+// Equal the two matrices are equal
+func (s *PermutedView) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *PermutedView) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *PermutedView) Size() int {
+	return s.matrix.Size()
+}
+
+// Values the number of stored elements in the matrix
+func (s *PermutedView) Values() int {
+	return s.matrix.Values()
+}
+
+// NVals the number of stored elements in the matrix
+func (s *PermutedView) NVals() int {
+	return s.matrix.NVals()
+}
+
+// Clear a view is read only and panics
+func (s *PermutedView) Clear() {
+	readOnly(0, 0)
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *PermutedView) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+func (s *PermutedView) iterator() *viewIterator {
+	iterator := s.matrix.Enumerate()
+	return newViewIterator(func() (int, int, float64, bool) {
+		if !iterator.HasNext() {
+			return 0, 0, 0, false
+		}
+		r, c, value := iterator.Next()
+		return permute(s.pInv, r), permute(s.qInv, c), value, true
+	})
+}
+
+// Map a view is read only, mapping any element panics
+func (s *PermutedView) Map() Map {
+	return s.iterator()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *PermutedView) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
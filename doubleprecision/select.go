@@ -0,0 +1,44 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+	"github.com/rossmerr/graphblas/binaryop/float64op"
+	float64UnaryOp "github.com/rossmerr/graphblas/unaryop/float64op"
+)
+
+// Select the elements of a matrix for which the operator is true
+//  C<M> ⊙= select(A, f)
+func Select(ctx context.Context, s Matrix, op float64UnaryOp.IndexUnaryOpFloat64, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, matrix Matrix) {
+	if s.Rows() != matrix.Rows() || s.Columns() != matrix.Columns() {
+		log.Panicf("Can not select %+v×%+v into %+v×%+v", s.Rows(), s.Columns(), matrix.Rows(), matrix.Columns())
+	}
+
+	t := newCOOMatrix(s.Rows(), s.Columns(), 0)
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			r, c, value := iterator.Next()
+			if op.Apply(r, c, value) {
+				t.Set(r, c, value)
+			}
+		}
+	}
+
+	accumulate(ctx, t, mask, accumulator, matrix)
+}
+
+// SelectVector the elements of a vector for which the operator is true, the column is always zero
+//  w<m> ⊙= select(u, f)
+func SelectVector(ctx context.Context, s Vector, op float64UnaryOp.IndexUnaryOpFloat64, mask GraphBLAS.Mask, accumulator float64op.BinaryOpFloat64, vector Vector) {
+	Select(ctx, s, op, mask, accumulator, vector)
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*SELLMatrix)(nil)).Elem())
+}
+
+// SELLMatrix sliced ELLPACK storage (SELL-C-σ), the rows are split into slices of C rows each padded to its longest row,
+// within each window of σ rows the rows are ordered longest first so rows of a similar length share a slice
+type SELLMatrix struct {
+	ell *slicedEll
+}
+
+// NewSELLMatrix returns a SELLMatrix with slices of chunk rows
+func NewSELLMatrix(r, c, chunk int) *SELLMatrix {
+	return &SELLMatrix{ell: newSlicedEll(r, c, chunk, identityOrder(r))}
+}
+
+// NewSELLMatrixFromMatrix returns a SELLMatrix holding the elements of the matrix with slices of chunk rows,
+// the rows are sorted within windows of sigma rows, later elements are added without moving rows between slices
+func NewSELLMatrixFromMatrix(m Matrix, chunk, sigma int) *SELLMatrix {
+	r := m.Rows()
+
+	length := make([]int, r)
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		row, _, _ := iterator.Next()
+		length[row]++
+	}
+
+	if sigma < 1 {
+		sigma = 1
+	}
+
+	order := identityOrder(r)
+	for start := 0; start < r; start += sigma {
+		end := start + sigma
+		if end > r {
+			end = r
+		}
+
+		window := order[start:end]
+		sort.SliceStable(window, func(i, j int) bool {
+			return length[window[i]] > length[window[j]]
+		})
+	}
+
+	return &SELLMatrix{ell: newSlicedEllFromMatrix(m, chunk, order)}
+}
+
+// Chunk the number of rows in a slice
+func (s *SELLMatrix) Chunk() int {
+	return s.ell.chunk
+}
+
+// Columns the number of columns of the matrix
+func (s *SELLMatrix) Columns() int {
+	return s.ell.c
+}
+
+// Rows the number of rows of the matrix
+func (s *SELLMatrix) Rows() int {
+	return s.ell.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *SELLMatrix) Update(r, c int, f func(float64) float64) {
+	s.ell.update(r, c, f)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *SELLMatrix) At(r, c int) float64 {
+	return s.ell.at(r, c)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *SELLMatrix) Has(r, c int) bool {
+	return s.ell.has(r, c)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *SELLMatrix) Remove(r, c int) {
+	s.ell.delete(r, c)
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *SELLMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *SELLMatrix) ColumnsAt(c int) Vector {
+	return s.ell.columnsAt(c)
+}
+
+// RowsAt return the rows at r-th
+func (s *SELLMatrix) RowsAt(r int) Vector {
+	return s.ell.rowsAt(r)
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *SELLMatrix) RowsAtToArray(r int) []float64 {
+	return s.ell.rowsAtToArray(r)
+}
+
+// Copy copies the matrix
+func (s *SELLMatrix) Copy() Matrix {
+	return &SELLMatrix{ell: s.ell.copy()}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *SELLMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *SELLMatrix) Multiply(m Matrix) Matrix {
+	matrix := s.empty(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *SELLMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *SELLMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *SELLMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *SELLMatrix) Transpose() Matrix {
+	matrix := NewCSRMatrix(s.Columns(), s.Rows())
+	Transpose(context.Background(), s, nil, matrix)
+	return s.from(matrix)
+}
+
+// Equal the two matrices are equal
+func (s *SELLMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *SELLMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *SELLMatrix) Size() int {
+	return s.Rows() * s.Columns()
+}
+
+// Values the number of stored elements in the matrix
+func (s *SELLMatrix) Values() int {
+	return s.ell.count
+}
+
+// NVals the number of stored elements in the matrix
+func (s *SELLMatrix) NVals() int {
+	return s.ell.count
+}
+
+// Clear removes all elements from a matrix
+func (s *SELLMatrix) Clear() {
+	s.ell.clear()
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *SELLMatrix) Enumerate() Enumerate {
+	return s.ell.iterator()
+}
+
+// Map replace each element with the result of applying a function to its value
+func (s *SELLMatrix) Map() Map {
+	return s.ell.iterator()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *SELLMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
+
+func (s *SELLMatrix) empty(r, c int) *SELLMatrix {
+	return NewSELLMatrix(r, c, s.ell.chunk)
+}
+
+func (s *SELLMatrix) from(m Matrix) *SELLMatrix {
+	return NewSELLMatrixFromMatrix(m, s.ell.chunk, 1)
+}
@@ -0,0 +1,416 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	GraphBLAS "github.com/rossmerr/graphblas"
+)
+
+// slicedEll the rows are split into slices of chunk rows, each slice is padded to its longest row
+// and stored column by column so the rows of a slice are read together
+type slicedEll struct {
+	r        int   // number of rows in the sparse matrix
+	c        int   // number of columns in the sparse matrix
+	chunk    int   // number of rows in a slice
+	rows     []int // the row held by each position, -1 for padding
+	position []int // the position of each row
+	length   []int // number of stored elements in each row
+	width    []int // the longest row of each slice
+	start    []int // the start of each slice, len(width)+1
+	cols     []int // -1 for padding
+	values   []float64
+	count    int
+}
+
+func newSlicedEll(r, c, chunk int, order []int) *slicedEll {
+	if chunk < 1 {
+		log.Panicf("Chunk '%+v' is invalid", chunk)
+	}
+
+	slices := (r + chunk - 1) / chunk
+	s := &slicedEll{
+		r:        r,
+		c:        c,
+		chunk:    chunk,
+		rows:     make([]int, slices*chunk),
+		position: make([]int, r),
+		length:   make([]int, r),
+		width:    make([]int, slices),
+		start:    make([]int, slices+1),
+	}
+
+	for p := range s.rows {
+		s.rows[p] = -1
+	}
+
+	for p, row := range order {
+		s.rows[p] = row
+		s.position[row] = p
+	}
+
+	return s
+}
+
+// newSlicedEllFromMatrix packs the rows of the matrix, order gives the row held by each position
+func newSlicedEllFromMatrix(m Matrix, chunk int, order []int) *slicedEll {
+	s := newSlicedEll(m.Rows(), m.Columns(), chunk, order)
+
+	cols := make([][]int, s.r)
+	values := make([][]float64, s.r)
+	for iterator := m.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		cols[r] = append(cols[r], c)
+		values[r] = append(values[r], value)
+	}
+
+	for r := range cols {
+		sort.Sort(&columnValues{cols[r], values[r]})
+		s.length[r] = len(cols[r])
+		s.count += len(cols[r])
+
+		if sl := s.position[r] / chunk; s.length[r] > s.width[sl] {
+			s.width[sl] = s.length[r]
+		}
+	}
+
+	for sl, w := range s.width {
+		s.start[sl+1] = s.start[sl] + w*chunk
+	}
+
+	s.cols = make([]int, s.start[len(s.width)])
+	s.values = make([]float64, len(s.cols))
+	for i := range s.cols {
+		s.cols[i] = -1
+	}
+
+	for r := range cols {
+		for k := range cols[r] {
+			i := s.slot(r, k)
+			s.cols[i] = cols[r][k]
+			s.values[i] = values[r][k]
+		}
+	}
+
+	return s
+}
+
+type columnValues struct {
+	cols   []int
+	values []float64
+}
+
+func (s *columnValues) Len() int {
+	return len(s.cols)
+}
+
+func (s *columnValues) Less(i, j int) bool {
+	return s.cols[i] < s.cols[j]
+}
+
+func (s *columnValues) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+func (s *slicedEll) copy() *slicedEll {
+	e := &slicedEll{
+		r:        s.r,
+		c:        s.c,
+		chunk:    s.chunk,
+		rows:     append([]int(nil), s.rows...),
+		position: append([]int(nil), s.position...),
+		length:   append([]int(nil), s.length...),
+		width:    append([]int(nil), s.width...),
+		start:    append([]int(nil), s.start...),
+		cols:     append([]int(nil), s.cols...),
+		values:   append([]float64(nil), s.values...),
+		count:    s.count,
+	}
+	return e
+}
+
+func (s *slicedEll) clear() {
+	for r := range s.length {
+		s.length[r] = 0
+	}
+	for sl := range s.width {
+		s.width[sl] = 0
+		s.start[sl+1] = 0
+	}
+	s.cols = make([]int, 0)
+	s.values = make([]float64, 0)
+	s.count = 0
+}
+
+func (s *slicedEll) check(r, c int) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+}
+
+// slot the index of the k-th element of the r-th row
+func (s *slicedEll) slot(r, k int) int {
+	p := s.position[r]
+	return s.start[p/s.chunk] + k*s.chunk + p%s.chunk
+}
+
+// find the k-th element of the r-th row holding the c-th column or where it would be inserted
+func (s *slicedEll) find(r, c int) (int, bool) {
+	k := sort.Search(s.length[r], func(k int) bool {
+		return s.cols[s.slot(r, k)] >= c
+	})
+	return k, k < s.length[r] && s.cols[s.slot(r, k)] == c
+}
+
+// widen adds a column of padding to the slice
+func (s *slicedEll) widen(sl int) {
+	end := s.start[sl+1]
+
+	padding := make([]int, s.chunk)
+	for i := range padding {
+		padding[i] = -1
+	}
+
+	s.cols = append(s.cols[:end], append(padding, s.cols[end:]...)...)
+	s.values = append(s.values[:end], append(make([]float64, s.chunk), s.values[end:]...)...)
+
+	s.width[sl]++
+	for i := sl + 1; i < len(s.start); i++ {
+		s.start[i] += s.chunk
+	}
+}
+
+func (s *slicedEll) insert(r, k, c int, value float64) {
+	if sl := s.position[r] / s.chunk; s.length[r] == s.width[sl] {
+		s.widen(sl)
+	}
+
+	for i := s.length[r]; i > k; i-- {
+		to, from := s.slot(r, i), s.slot(r, i-1)
+		s.cols[to], s.values[to] = s.cols[from], s.values[from]
+	}
+
+	i := s.slot(r, k)
+	s.cols[i], s.values[i] = c, value
+	s.length[r]++
+	s.count++
+}
+
+func (s *slicedEll) remove(r, k int) {
+	for i := k; i < s.length[r]-1; i++ {
+		to, from := s.slot(r, i), s.slot(r, i+1)
+		s.cols[to], s.values[to] = s.cols[from], s.values[from]
+	}
+
+	s.length[r]--
+	i := s.slot(r, s.length[r])
+	s.cols[i], s.values[i] = -1, 0
+	s.count--
+}
+
+func (s *slicedEll) update(r, c int, f func(float64) float64) {
+	s.check(r, c)
+
+	k, found := s.find(r, c)
+	if found {
+		i := s.slot(r, k)
+		s.values[i] = f(s.values[i])
+	} else {
+		s.insert(r, k, c, f(0))
+	}
+}
+
+func (s *slicedEll) at(r, c int) float64 {
+	s.check(r, c)
+
+	if k, found := s.find(r, c); found {
+		return s.values[s.slot(r, k)]
+	}
+
+	return 0
+}
+
+func (s *slicedEll) has(r, c int) bool {
+	s.check(r, c)
+
+	_, found := s.find(r, c)
+	return found
+}
+
+func (s *slicedEll) delete(r, c int) {
+	s.check(r, c)
+
+	if k, found := s.find(r, c); found {
+		s.remove(r, k)
+	}
+}
+
+func (s *slicedEll) columnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+
+	for r := 0; r < s.r; r++ {
+		if k, found := s.find(r, c); found {
+			columns.SetVec(r, s.values[s.slot(r, k)])
+		}
+	}
+
+	return columns
+}
+
+func (s *slicedEll) rowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+
+	for k := 0; k < s.length[r]; k++ {
+		i := s.slot(r, k)
+		rows.SetVec(s.cols[i], s.values[i])
+	}
+
+	return rows
+}
+
+func (s *slicedEll) rowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+
+	for k := 0; k < s.length[r]; k++ {
+		i := s.slot(r, k)
+		rows[s.cols[i]] = s.values[i]
+	}
+
+	return rows
+}
+
+// multiplyVector the matrix vector product slice by slice
+//  y = Ax
+func (s *slicedEll) multiplyVector(ctx context.Context, m Vector, mask GraphBLAS.Mask, vector Vector) {
+	if m.Rows() != s.c {
+		log.Panicf("Can not multiply matrices found length mismatch %+v, %+v", m.Rows(), s.c)
+	}
+
+	if mask == nil {
+		mask = GraphBLAS.NewEmptyMask(vector.Rows(), vector.Columns())
+	}
+
+	if mask.Rows() != vector.Rows() {
+		log.Panicf("Can not apply mask found rows mismatch %+v, %+v", mask.Rows(), vector.Rows())
+	}
+
+	if mask.Columns() != vector.Columns() {
+		log.Panicf("Can not apply mask found columns mismatch %+v, %+v", mask.Columns(), vector.Columns())
+	}
+
+	// a dense vector without a removed element has every element stored
+	var x []float64
+	var present []bool
+	if dense, ok := m.(*DenseVector); ok && dense.present == nil {
+		x = dense.values
+	} else {
+		x = make([]float64, s.c)
+		present = make([]bool, s.c)
+		for iterator := m.Enumerate(); iterator.HasNext(); {
+			r, _, value := iterator.Next()
+			x[r] = value
+			present[r] = true
+		}
+	}
+
+	y := make([]float64, len(s.rows))
+	stored := make([]bool, len(s.rows))
+
+	for sl, width := range s.width {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		lanes := y[sl*s.chunk : (sl+1)*s.chunk]
+		for k := 0; k < width; k++ {
+			offset := s.start[sl] + k*s.chunk
+			cols := s.cols[offset : offset+s.chunk]
+			values := s.values[offset : offset+s.chunk]
+			for lane, c := range cols {
+				if c >= 0 && (present == nil || present[c]) {
+					lanes[lane] += values[lane] * x[c]
+					stored[sl*s.chunk+lane] = true
+				}
+			}
+		}
+	}
+
+	for p, r := range s.rows {
+		if r < 0 || mask.Element(r, 0) {
+			continue
+		}
+
+		if stored[p] {
+			vector.SetVec(r, y[p])
+		} else if vector.HasVec(r) {
+			vector.RemoveVec(r)
+		}
+	}
+}
+
+type slicedEllIterator struct {
+	matrix *slicedEll
+	last   int
+	r      int
+	k      int
+	index  int
+}
+
+func (s *slicedEll) iterator() *slicedEllIterator {
+	return &slicedEllIterator{
+		matrix: s,
+		k:      -1,
+	}
+}
+
+// HasNext checks the iterator has any more values
+func (s *slicedEllIterator) HasNext() bool {
+	return s.last < s.matrix.count
+}
+
+func (s *slicedEllIterator) next() {
+	s.k++
+	for s.k >= s.matrix.length[s.r] {
+		s.r++
+		s.k = 0
+	}
+	s.index = s.matrix.slot(s.r, s.k)
+	s.last++
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *slicedEllIterator) Next() (int, int, float64) {
+	s.next()
+	return s.r, s.matrix.cols[s.index], s.matrix.values[s.index]
+}
+
+// Map move the iterator and uses a higher order function to changes the elements current value
+func (s *slicedEllIterator) Map(f func(int, int, float64) float64) {
+	s.next()
+	s.matrix.values[s.index] = f(s.r, s.matrix.cols[s.index], s.matrix.values[s.index])
+}
@@ -21,8 +21,17 @@ func RegisterMatrix(matrix reflect.Type) {
 
 }
 
-// IsSparseMatrix is 's' a sparse matrix
+// wrapper a matrix that keeps its elements in another matrix, such as a view
+type wrapper interface {
+	wrapped() Matrix
+}
+
+// IsSparseMatrix is 's' a sparse matrix, a view or wrapper is sparse when the matrix it wraps is sparse
 func IsSparseMatrix(s Matrix) bool {
+	if w, ok := s.(wrapper); ok {
+		return IsSparseMatrix(w.wrapped())
+	}
+
 	t := reflect.TypeOf(s).Elem()
 	_, found := sparseMatrixRegistry[t.Name()]
 	return found
@@ -33,6 +33,36 @@ func TestSparseMatrixRegistry_IsSparseMatrix(t *testing.T) {
 			s:        doubleprecision.NewCSRMatrix(2, 2),
 			isSparse: true,
 		},
+		{
+			name:     "MutexMatrix",
+			s:        doubleprecision.NewMutexMatrix(doubleprecision.NewCSRMatrix(2, 2)),
+			isSparse: true,
+		},
+		{
+			name:     "TransposeView of DenseMatrix",
+			s:        doubleprecision.NewTransposeView(doubleprecision.NewDenseMatrix(2, 2)),
+			isSparse: false,
+		},
+		{
+			name:     "SubMatrix of DenseMatrix",
+			s:        doubleprecision.NewSubMatrix(doubleprecision.NewDenseMatrix(2, 2), 0, 0, 1, 1),
+			isSparse: false,
+		},
+		{
+			name:     "SubMatrix of CSRMatrix",
+			s:        doubleprecision.NewSubMatrix(doubleprecision.NewCSRMatrix(2, 2), 0, 0, 1, 1),
+			isSparse: true,
+		},
+		{
+			name:     "PermutedView of DenseMatrix",
+			s:        doubleprecision.NewPermutedView(doubleprecision.NewDenseMatrix(2, 2), []int{1, 0}, []int{1, 0}),
+			isSparse: false,
+		},
+		{
+			name:     "DiagonalView of MutexMatrix of DenseMatrix",
+			s:        doubleprecision.NewDiagonalView(doubleprecision.NewMutexMatrix(doubleprecision.NewDenseMatrix(2, 2)), 0),
+			isSparse: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
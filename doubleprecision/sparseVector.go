@@ -33,7 +33,9 @@ func NewSparseVectorFromArray(data []float64) *SparseVector {
 	s := newSparseVector(l, 0)
 
 	for i := 0; i < l; i++ {
-		s.SetVec(i, data[i])
+		if data[i] != 0 {
+			s.SetVec(i, data[i])
+		}
 	}
 
 	return s
@@ -72,16 +74,34 @@ func (s *SparseVector) SetVec(i int, value float64) {
 	pointer, length, _ := s.index(i)
 
 	if pointer < length && s.indices[pointer] == i {
-		if value == 0 {
-			s.remove(pointer)
-		} else {
-			s.values[pointer] = value
-		}
+		s.values[pointer] = value
 	} else {
 		s.insert(pointer, i, value)
 	}
 }
 
+// HasVec the element at i-th is stored
+func (s *SparseVector) HasVec(i int) bool {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	pointer, length, _ := s.index(i)
+	return pointer < length && s.indices[pointer] == i
+}
+
+// RemoveVec removes the stored element at i-th
+func (s *SparseVector) RemoveVec(i int) {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	pointer, length, _ := s.index(i)
+	if pointer < length && s.indices[pointer] == i {
+		s.remove(pointer)
+	}
+}
+
 // Columns the number of columns of the vector
 func (s *SparseVector) Columns() int {
 	return 1
@@ -107,13 +127,30 @@ func (s *SparseVector) Update(r, c int, f func(float64) float64) {
 }
 
 // At returns the value of a vector element at r-th, c-th
-func (s *SparseVector) At(r, c int) (value float64) {
-	s.Update(r, c, func(v float64) float64 {
-		value = v
-		return v
-	})
+func (s *SparseVector) At(r, c int) float64 {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
 
-	return
+	return s.AtVec(r)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *SparseVector) Has(r, c int) bool {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return s.HasVec(r)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *SparseVector) Remove(r, c int) {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	s.RemoveVec(r)
 }
 
 // Set sets the value at r-th, c-th of the vector
@@ -146,8 +183,9 @@ func (s *SparseVector) RowsAt(r int) Vector {
 
 	rows := NewSparseVector(1)
 
-	v := s.AtVec(r)
-	rows.SetVec(0, v)
+	if s.HasVec(r) {
+		rows.SetVec(0, s.AtVec(r))
+	}
 
 	return rows
 }
@@ -167,10 +205,6 @@ func (s *SparseVector) RowsAtToArray(r int) []float64 {
 }
 
 func (s *SparseVector) insert(pointer, i int, value float64) {
-	if value == 0 {
-		return
-	}
-
 	s.indices = append(s.indices[:pointer], append([]int{i}, s.indices[pointer:]...)...)
 	s.values = append(s.values[:pointer], append([]float64{value}, s.values[pointer:]...)...)
 }
@@ -182,7 +216,7 @@ func (s *SparseVector) remove(pointer int) {
 
 func (s *SparseVector) index(i int) (int, int, error) {
 	length := len(s.indices)
-	if i > length {
+	if length == 0 || i > s.indices[length-1] {
 		return length, length, nil
 	}
 
@@ -240,7 +274,7 @@ func (s *SparseVector) Add(m Matrix) Matrix {
 
 // Subtract subtracts one metrix from another metrix
 func (s *SparseVector) Subtract(m Matrix) Matrix {
-	matrix := m.Copy()
+	matrix := s.Copy()
 	Subtract(context.Background(), s, m, nil, matrix)
 	return matrix
 }
@@ -274,11 +308,16 @@ func (s *SparseVector) Size() int {
 	return s.l
 }
 
-// Values the number of non-zero elements in the Vector
+// Values the number of stored elements in the Vector
 func (s *SparseVector) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the Vector
+func (s *SparseVector) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a vector
 func (s *SparseVector) Clear() {
 	s.values = make([]float64, 0)
@@ -286,7 +325,7 @@ func (s *SparseVector) Clear() {
 
 }
 
-// Enumerate iterates through all non-zero elements, order is not guaranteed
+// Enumerate iterates through all stored elements, order is not guaranteed
 func (s *SparseVector) Enumerate() Enumerate {
 	return s.iterator()
 }
@@ -347,12 +386,7 @@ func (s *sparseVectorMap) HasNext() bool {
 func (s *sparseVectorMap) Map(f func(int, int, float64) float64) {
 	s.next()
 
-	value := f(s.matrix.indices[s.old], 0, s.matrix.values[s.old])
-	if value != 0 {
-		s.matrix.values[s.old] = value
-	} else {
-		s.matrix.remove(s.old)
-	}
+	s.matrix.values[s.old] = f(s.matrix.indices[s.old], 0, s.matrix.values[s.old])
 }
 
 // Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
@@ -0,0 +1,204 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package stats summarises the structure of a graph held as an adjacency matrix
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/math/symmetric"
+)
+
+// Degree summary of the in or out degree of every vertex
+type Degree struct {
+	Min  int     `json:"min"`
+	Max  int     `json:"max"`
+	Mean float64 `json:"mean"`
+	// Distribution the number of vertices with each degree
+	Distribution map[int]int `json:"distribution"`
+}
+
+// Statistics of a graph, every stored element of the adjacency matrix is an edge including those with a weight of zero
+type Statistics struct {
+	Vertices  int `json:"vertices"`
+	Edges     int `json:"edges"`
+	SelfLoops int `json:"selfLoops"`
+	// Isolated the number of vertices without any edge, a self loop is an edge so a vertex with only a self loop is not isolated
+	Isolated  int     `json:"isolated"`
+	Density   float64 `json:"density"`
+	InDegree  Degree  `json:"inDegree"`
+	OutDegree Degree  `json:"outDegree"`
+	// Diameter a lower bound on the diameter of the underlying undirected graph found by a double-sweep breadth-first search
+	Diameter  int  `json:"diameter"`
+	Symmetric bool `json:"symmetric"`
+	// Components the number of weakly connected components
+	Components int `json:"components"`
+}
+
+// Compute the statistics of the graph, returns the context error when cancelled before the statistics are complete
+func Compute(ctx context.Context, a doubleprecision.Matrix) (*Statistics, error) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not compute statistics of a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	n := a.Rows()
+	in := make([]int, n)
+	out := make([]int, n)
+	undirected := make([][]int, n)
+
+	s := &Statistics{Vertices: n}
+
+	for iterator := a.Enumerate(); iterator.HasNext(); {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		r, c, _ := iterator.Next()
+
+		s.Edges++
+		out[r]++
+		in[c]++
+
+		if r == c {
+			s.SelfLoops++
+			continue
+		}
+
+		undirected[r] = append(undirected[r], c)
+		undirected[c] = append(undirected[c], r)
+	}
+
+	for v := 0; v < n; v++ {
+		if in[v] == 0 && out[v] == 0 {
+			s.Isolated++
+		}
+	}
+
+	if n > 1 {
+		s.Density = float64(s.Edges-s.SelfLoops) / float64(n*(n-1))
+	}
+
+	s.InDegree = degree(in)
+	s.OutDegree = degree(out)
+	s.Symmetric = symmetric.Symmetric(a)
+	s.Components, s.Diameter = components(undirected)
+
+	return s, nil
+}
+
+func degree(degrees []int) Degree {
+	d := Degree{Distribution: make(map[int]int)}
+	if len(degrees) == 0 {
+		return d
+	}
+
+	d.Min = degrees[0]
+	total := 0
+	for _, v := range degrees {
+		if v < d.Min {
+			d.Min = v
+		}
+		if v > d.Max {
+			d.Max = v
+		}
+		total += v
+		d.Distribution[v]++
+	}
+	d.Mean = float64(total) / float64(len(degrees))
+
+	return d
+}
+
+// breadthFirst returns the distance of every vertex reached from source, unreached vertices are -1
+func breadthFirst(undirected [][]int, source int) (distance []int, farthest int) {
+	distance = make([]int, len(undirected))
+	for i := range distance {
+		distance[i] = -1
+	}
+
+	distance[source] = 0
+	farthest = source
+	frontier := []int{source}
+	for len(frontier) > 0 {
+		next := []int{}
+		for _, v := range frontier {
+			for _, u := range undirected[v] {
+				if distance[u] < 0 {
+					distance[u] = distance[v] + 1
+					if distance[u] > distance[farthest] {
+						farthest = u
+					}
+					next = append(next, u)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return
+}
+
+// components counts the connected components and runs a double sweep in each to approximate the diameter
+func components(undirected [][]int) (count, diameter int) {
+	seen := make([]bool, len(undirected))
+	for v := range undirected {
+		if seen[v] {
+			continue
+		}
+		count++
+
+		distance, farthest := breadthFirst(undirected, v)
+		for u, d := range distance {
+			if d >= 0 {
+				seen[u] = true
+			}
+		}
+
+		distance, farthest = breadthFirst(undirected, farthest)
+		if distance[farthest] > diameter {
+			diameter = distance[farthest]
+		}
+	}
+
+	return
+}
+
+func (s Degree) String() string {
+	keys := make([]int, 0, len(s.Distribution))
+	for k := range s.Distribution {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	distribution := make([]string, len(keys))
+	for i, k := range keys {
+		distribution[i] = fmt.Sprintf("%d:%d", k, s.Distribution[k])
+	}
+
+	return fmt.Sprintf("min %d, max %d, mean %.4g [%s]", s.Min, s.Max, s.Mean, strings.Join(distribution, " "))
+}
+
+func (s *Statistics) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "vertices:   %d\n", s.Vertices)
+	fmt.Fprintf(&b, "edges:      %d\n", s.Edges)
+	fmt.Fprintf(&b, "self loops: %d\n", s.SelfLoops)
+	fmt.Fprintf(&b, "isolated:   %d\n", s.Isolated)
+	fmt.Fprintf(&b, "density:    %.4g\n", s.Density)
+	fmt.Fprintf(&b, "in degree:  %s\n", s.InDegree)
+	fmt.Fprintf(&b, "out degree: %s\n", s.OutDegree)
+	fmt.Fprintf(&b, "diameter:   %d\n", s.Diameter)
+	fmt.Fprintf(&b, "symmetric:  %t\n", s.Symmetric)
+	fmt.Fprintf(&b, "components: %d\n", s.Components)
+	return b.String()
+}
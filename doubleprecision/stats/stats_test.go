@@ -0,0 +1,159 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package stats_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/stats"
+)
+
+func TestCompute(t *testing.T) {
+	// path 0 -> 1 -> 2 -> 3, a self loop on 4 which is not isolated and an isolated vertex 5
+	array := [][]float64{
+		[]float64{0, 1, 0, 0, 0, 0},
+		[]float64{0, 0, 1, 0, 0, 0},
+		[]float64{0, 0, 0, 1, 0, 0},
+		[]float64{0, 0, 0, 0, 0, 0},
+		[]float64{0, 0, 0, 0, 1, 0},
+		[]float64{0, 0, 0, 0, 0, 0},
+	}
+
+	want := &stats.Statistics{
+		Vertices:  6,
+		Edges:     4,
+		SelfLoops: 1,
+		Isolated:  1,
+		Density:   0.1,
+		InDegree: stats.Degree{
+			Min:          0,
+			Max:          1,
+			Mean:         4.0 / 6,
+			Distribution: map[int]int{0: 2, 1: 4},
+		},
+		OutDegree: stats.Degree{
+			Min:          0,
+			Max:          1,
+			Mean:         4.0 / 6,
+			Distribution: map[int]int{0: 2, 1: 4},
+		},
+		Diameter:   3,
+		Symmetric:  false,
+		Components: 3,
+	}
+
+	// a dense matrix stores every element so the missing edges are removed
+	dense := doubleprecision.NewDenseMatrixFromArray(array)
+	for r, row := range array {
+		for c, value := range row {
+			if value == 0 {
+				dense.Remove(r, c)
+			}
+		}
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Matrix
+	}{
+		{
+			name: "DenseMatrix",
+			s:    dense,
+		},
+		{
+			name: "CSCMatrix",
+			s:    doubleprecision.NewCSCMatrixFromArray(array),
+		},
+		{
+			name: "CSRMatrix",
+			s:    doubleprecision.NewCSRMatrixFromArray(array),
+		},
+		{
+			name: "MutexMatrix",
+			s:    doubleprecision.NewMutexMatrix(doubleprecision.NewCSRMatrixFromArray(array)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stats.Compute(context.Background(), tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%+v Compute = %+v, want %+v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func TestCompute_JSON(t *testing.T) {
+	array := [][]float64{
+		[]float64{0, 1},
+		[]float64{1, 0},
+	}
+	got, err := stats.Compute(context.Background(), doubleprecision.NewCSRMatrixFromArray(array))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Symmetric {
+		t.Errorf("Compute Symmetric = %+v, want %+v", got.Symmetric, true)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &stats.Statistics{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, decoded) {
+		t.Errorf("json round trip = %+v, want %+v", decoded, got)
+	}
+
+	if got.String() == "" {
+		t.Errorf("String is empty")
+	}
+}
+
+func TestCompute_StoredZero(t *testing.T) {
+	s := doubleprecision.NewCSRMatrix(3, 3)
+	s.Set(0, 1, 0)
+	s.Set(2, 2, 0)
+
+	got, err := stats.Compute(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Edges != 2 {
+		t.Errorf("Compute Edges = %+v, want %+v", got.Edges, 2)
+	}
+	if got.SelfLoops != 1 {
+		t.Errorf("Compute SelfLoops = %+v, want %+v", got.SelfLoops, 1)
+	}
+	if got.Isolated != 0 {
+		t.Errorf("Compute Isolated = %+v, want %+v", got.Isolated, 0)
+	}
+}
+
+func TestCompute_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := doubleprecision.NewCSRMatrixFromArray([][]float64{
+		[]float64{0, 1},
+		[]float64{1, 0},
+	})
+	if got, err := stats.Compute(ctx, s); got != nil || err != context.Canceled {
+		t.Errorf("Compute = %+v, %+v, want %+v, %+v", got, err, nil, context.Canceled)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+)
+
+// SubMatrix a read only view of a block of a matrix, elements are read from the matrix without copying
+type SubMatrix struct {
+	matrix Matrix
+	r0     int // first row of the block
+	c0     int // first column of the block
+	r      int // number of rows in the block
+	c      int // number of columns in the block
+}
+
+// NewSubMatrix returns a SubMatrix of the rows r0 to r0+rows and columns c0 to c0+cols of the matrix
+func NewSubMatrix(m Matrix, r0, c0, rows, cols int) *SubMatrix {
+	if r0 < 0 || rows < 0 || r0+rows > m.Rows() {
+		log.Panicf("Can not view rows %+v to %+v of %+v", r0, r0+rows, m.Rows())
+	}
+
+	if c0 < 0 || cols < 0 || c0+cols > m.Columns() {
+		log.Panicf("Can not view columns %+v to %+v of %+v", c0, c0+cols, m.Columns())
+	}
+
+	if sub, ok := m.(*SubMatrix); ok {
+		return &SubMatrix{matrix: sub.matrix, r0: sub.r0 + r0, c0: sub.c0 + c0, r: rows, c: cols}
+	}
+
+	return &SubMatrix{matrix: m, r0: r0, c0: c0, r: rows, c: cols}
+}
+
+func (s *SubMatrix) checkBounds(r, c int) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+}
+
+// wrapped the viewed matrix
+func (s *SubMatrix) wrapped() Matrix {
+	return s.matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *SubMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *SubMatrix) Rows() int {
+	return s.r
+}
+
+// Update a view is read only and panics
+func (s *SubMatrix) Update(r, c int, f func(float64) float64) {
+	readOnly(r, c)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *SubMatrix) At(r, c int) float64 {
+	s.checkBounds(r, c)
+	return s.matrix.At(s.r0+r, s.c0+c)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *SubMatrix) Has(r, c int) bool {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return s.matrix.Has(s.r0+r, s.c0+c)
+}
+
+// Remove a view is read only and panics
+func (s *SubMatrix) Remove(r, c int) {
+	readOnly(r, c)
+}
+
+// Set a view is read only and panics
+func (s *SubMatrix) Set(r, c int, value float64) {
+	readOnly(r, c)
+}
+
+// ColumnsAt return the columns at c-th
+func (s *SubMatrix) ColumnsAt(c int) Vector {
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	columns := NewSparseVector(s.r)
+	for r := 0; r < s.r; r++ {
+		if s.matrix.Has(s.r0+r, s.c0+c) {
+			columns.SetVec(r, s.matrix.At(s.r0+r, s.c0+c))
+		}
+	}
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *SubMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.c)
+	for iterator := s.row(r); iterator.HasNext(); {
+		_, c, value := iterator.Next()
+		rows.SetVec(c, value)
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *SubMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.c)
+	for iterator := s.row(r); iterator.HasNext(); {
+		_, c, value := iterator.Next()
+		rows[c] = value
+	}
+	return rows
+}
+
+// row iterates the stored elements of the r-th row of the block
+func (s *SubMatrix) row(r int) *viewIterator {
+	if csr, ok := s.matrix.(*CSRMatrix); ok {
+		pointer := csr.rowStart[s.r0+r]
+		end := csr.rowStart[s.r0+r+1]
+		return newViewIterator(func() (int, int, float64, bool) {
+			for ; pointer < end; pointer++ {
+				c := csr.cols[pointer] - s.c0
+				if c >= 0 && c < s.c {
+					pointer++
+					return r, c, csr.values[pointer-1], true
+				}
+			}
+			return 0, 0, 0, false
+		})
+	}
+
+	c := 0
+	return newViewIterator(func() (int, int, float64, bool) {
+		for ; c < s.c; c++ {
+			if s.matrix.Has(s.r0+r, s.c0+c) {
+				c++
+				return r, c - 1, s.matrix.At(s.r0+r, s.c0+c-1), true
+			}
+		}
+		return 0, 0, 0, false
+	})
+}
+
+// Copy copies the elements of the view into a new matrix
+func (s *SubMatrix) Copy() Matrix {
+	return materialise(s, s.matrix)
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *SubMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *SubMatrix) Multiply(m Matrix) Matrix {
+	matrix := newMaterialised(s.Rows(), m.Columns(), s.matrix)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *SubMatrix) Add(m Matrix) Matrix {
+	matrix := s.Copy()
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *SubMatrix) Subtract(m Matrix) Matrix {
+	matrix := s.Copy()
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *SubMatrix) Negative() Matrix {
+	matrix := s.Copy()
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns
+func (s *SubMatrix) Transpose() Matrix {
+	return s.Copy().Transpose()
+}
+
+// Equal the two matrices are equal
+func (s *SubMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *SubMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *SubMatrix) Size() int {
+	return s.r * s.c
+}
+
+// Values the number of stored elements in the block
+func (s *SubMatrix) Values() int {
+	return s.NVals()
+}
+
+// NVals the number of stored elements in the block
+func (s *SubMatrix) NVals() int {
+	count := 0
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		iterator.Next()
+		count++
+	}
+	return count
+}
+
+// Clear a view is read only and panics
+func (s *SubMatrix) Clear() {
+	readOnly(s.r0, s.c0)
+}
+
+// Enumerate iterates through all stored elements of the block by row
+func (s *SubMatrix) Enumerate() Enumerate {
+	r := -1
+	var row *viewIterator
+
+	return newViewIterator(func() (int, int, float64, bool) {
+		for row == nil || !row.HasNext() {
+			r++
+			if r >= s.r {
+				return 0, 0, 0, false
+			}
+			row = s.row(r)
+		}
+		_, c, value := row.Next()
+		return r, c, value, true
+	})
+}
+
+// Map a view is read only, mapping any element panics
+func (s *SubMatrix) Map() Map {
+	return s.Enumerate().(*viewIterator)
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *SubMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
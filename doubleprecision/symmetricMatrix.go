@@ -0,0 +1,288 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*SymmetricMatrix)(nil)).Elem())
+}
+
+// SymmetricMatrix a square matrix equal to its transpose, only the upper triangle is stored
+// and the lower triangle mirrors it
+type SymmetricMatrix struct {
+	n     int // number of rows and columns in the matrix
+	upper *CSRMatrix
+	above [][]int // the rows stored above the diagonal of each column, sorted
+}
+
+// NewSymmetricMatrix returns a SymmetricMatrix
+func NewSymmetricMatrix(n int) *SymmetricMatrix {
+	return &SymmetricMatrix{n: n, upper: NewCSRMatrix(n, n), above: make([][]int, n)}
+}
+
+// NewSymmetricMatrixFromArray returns a SymmetricMatrix, only the upper triangle of the data is read
+func NewSymmetricMatrixFromArray(data [][]float64) *SymmetricMatrix {
+	n := len(data)
+	if n > 0 && len(data[0]) != n {
+		log.Panicf("Can not make a symmetric matrix from %+v×%+v", n, len(data[0]))
+	}
+
+	s := NewSymmetricMatrix(n)
+	for i := 0; i < n; i++ {
+		for k := i; k < n; k++ {
+			if data[i][k] != 0 {
+				s.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+// upperTriangle the element of the upper triangle mirroring r-th, c-th
+func upperTriangle(r, c int) (int, int) {
+	if r > c {
+		return c, r
+	}
+	return r, c
+}
+
+// Columns the number of columns of the matrix
+func (s *SymmetricMatrix) Columns() int {
+	return s.n
+}
+
+// Rows the number of rows of the matrix
+func (s *SymmetricMatrix) Rows() int {
+	return s.n
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th and its mirror
+func (s *SymmetricMatrix) Update(r, c int, f func(float64) float64) {
+	r, c = upperTriangle(r, c)
+	stored := s.upper.Has(r, c)
+	s.upper.Update(r, c, f)
+
+	if !stored && r != c {
+		rows := s.above[c]
+		i := sort.SearchInts(rows, r)
+		rows = append(rows, 0)
+		copy(rows[i+1:], rows[i:])
+		rows[i] = r
+		s.above[c] = rows
+	}
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *SymmetricMatrix) At(r, c int) float64 {
+	r, c = upperTriangle(r, c)
+	return s.upper.At(r, c)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *SymmetricMatrix) Has(r, c int) bool {
+	r, c = upperTriangle(r, c)
+	return s.upper.Has(r, c)
+}
+
+// Remove removes the stored element at r-th, c-th and its mirror
+func (s *SymmetricMatrix) Remove(r, c int) {
+	r, c = upperTriangle(r, c)
+	if !s.upper.Has(r, c) {
+		return
+	}
+	s.upper.Remove(r, c)
+
+	if r != c {
+		rows := s.above[c]
+		i := sort.SearchInts(rows, r)
+		s.above[c] = append(rows[:i], rows[i+1:]...)
+	}
+}
+
+// Set sets the value at r-th, c-th of the matrix and its mirror
+func (s *SymmetricMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *SymmetricMatrix) ColumnsAt(c int) Vector {
+	return s.RowsAt(c)
+}
+
+// RowsAt return the rows at r-th
+func (s *SymmetricMatrix) RowsAt(r int) Vector {
+	if r < 0 || r >= s.n {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := NewSparseVector(s.n)
+
+	s.row(r, func(c int, value float64) {
+		rows.SetVec(c, value)
+	})
+
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *SymmetricMatrix) RowsAtToArray(r int) []float64 {
+	if r < 0 || r >= s.n {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	rows := make([]float64, s.n)
+
+	s.row(r, func(c int, value float64) {
+		rows[c] = value
+	})
+
+	return rows
+}
+
+// row visits the r-th row, the part left of the diagonal is read from the r-th column of the upper triangle
+func (s *SymmetricMatrix) row(r int, f func(int, float64)) {
+	for _, i := range s.above[r] {
+		pointer, _ := s.upper.pointer(i, r)
+		f(i, s.upper.values[pointer])
+	}
+
+	for i := s.upper.rowStart[r]; i < s.upper.rowStart[r+1]; i++ {
+		f(s.upper.cols[i], s.upper.values[i])
+	}
+}
+
+// Copy copies the matrix
+func (s *SymmetricMatrix) Copy() Matrix {
+	above := make([][]int, s.n)
+	for c, rows := range s.above {
+		above[c] = append([]int(nil), rows...)
+	}
+	return &SymmetricMatrix{n: s.n, upper: s.upper.Copy().(*CSRMatrix), above: above}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *SymmetricMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *SymmetricMatrix) Multiply(m Matrix) Matrix {
+	matrix := NewCSRMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix, the result is a CSRMatrix
+func (s *SymmetricMatrix) Add(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix, the result is a CSRMatrix
+func (s *SymmetricMatrix) Subtract(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix, the result is a CSRMatrix
+func (s *SymmetricMatrix) Negative() Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns, a symmetric matrix is its own transpose
+func (s *SymmetricMatrix) Transpose() Matrix {
+	return s.Copy()
+}
+
+// Equal the two matrices are equal
+func (s *SymmetricMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *SymmetricMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *SymmetricMatrix) Size() int {
+	return s.n * s.n
+}
+
+// Values the number of stored elements in the matrix counting both mirrors
+func (s *SymmetricMatrix) Values() int {
+	return s.NVals()
+}
+
+// NVals the number of stored elements in the matrix counting both mirrors
+func (s *SymmetricMatrix) NVals() int {
+	diagonal := 0
+	for r := 0; r < s.n; r++ {
+		if s.upper.Has(r, r) {
+			diagonal++
+		}
+	}
+	return 2*s.upper.NVals() - diagonal
+}
+
+// Clear removes all elements from a matrix
+func (s *SymmetricMatrix) Clear() {
+	s.upper.Clear()
+	s.above = make([][]int, s.n)
+}
+
+// Enumerate iterates through all stored elements of both triangles, order is not guaranteed
+func (s *SymmetricMatrix) Enumerate() Enumerate {
+	return &symmetricMatrixIterator{iterator: s.upper.iterator()}
+}
+
+type symmetricMatrixIterator struct {
+	iterator *cSRMatrixIterator
+	mirror   bool
+	r        int
+	c        int
+	value    float64
+}
+
+// HasNext checks the iterator has any more values
+func (s *symmetricMatrixIterator) HasNext() bool {
+	return s.mirror || s.iterator.HasNext()
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *symmetricMatrixIterator) Next() (int, int, float64) {
+	if s.mirror {
+		s.mirror = false
+		return s.c, s.r, s.value
+	}
+
+	s.r, s.c, s.value = s.iterator.Next()
+	s.mirror = s.r != s.c
+	return s.r, s.c, s.value
+}
+
+// Map replace each element of the upper triangle with the result of applying a function to its value,
+// the lower triangle mirrors the result
+func (s *SymmetricMatrix) Map() Map {
+	return s.upper.Map()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *SymmetricMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import "context"
+
+// TransposeView a read only view of the transpose of a matrix, elements are read from the matrix without copying
+type TransposeView struct {
+	matrix Matrix
+}
+
+// NewTransposeView returns a TransposeView of the matrix
+func NewTransposeView(m Matrix) *TransposeView {
+	return &TransposeView{matrix: m}
+}
+
+// wrapped the viewed matrix
+func (s *TransposeView) wrapped() Matrix {
+	return s.matrix
+}
+
+// Columns the number of columns of the matrix
+func (s *TransposeView) Columns() int {
+	return s.matrix.Rows()
+}
+
+// Rows the number of rows of the matrix
+func (s *TransposeView) Rows() int {
+	return s.matrix.Columns()
+}
+
+// Update a view is read only and panics
+func (s *TransposeView) Update(r, c int, f func(float64) float64) {
+	readOnly(r, c)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *TransposeView) At(r, c int) float64 {
+	return s.matrix.At(c, r)
+}
+
+// Has the element at r-th, c-th is stored
+func (s *TransposeView) Has(r, c int) bool {
+	return s.matrix.Has(c, r)
+}
+
+// Remove a view is read only and panics
+func (s *TransposeView) Remove(r, c int) {
+	readOnly(r, c)
+}
+
+// Set a view is read only and panics
+func (s *TransposeView) Set(r, c int, value float64) {
+	readOnly(r, c)
+}
+
+// ColumnsAt return the columns at c-th
+func (s *TransposeView) ColumnsAt(c int) Vector {
+	return s.matrix.RowsAt(c)
+}
+
+// RowsAt return the rows at r-th
+func (s *TransposeView) RowsAt(r int) Vector {
+	return s.matrix.ColumnsAt(r)
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *TransposeView) RowsAtToArray(r int) []float64 {
+	rows := make([]float64, s.Columns())
+	for iterator := s.matrix.ColumnsAt(r).Enumerate(); iterator.HasNext(); {
+		c, _, value := iterator.Next()
+		rows[c] = value
+	}
+	return rows
+}
+
+// Copy copies the elements of the view into a new matrix
+func (s *TransposeView) Copy() Matrix {
+	return s.matrix.Transpose()
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *TransposeView) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), s, alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *TransposeView) Multiply(m Matrix) Matrix {
+	matrix := newMaterialised(s.Rows(), m.Columns(), s.matrix)
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix
+func (s *TransposeView) Add(m Matrix) Matrix {
+	matrix := materialise(s, s.matrix)
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix
+func (s *TransposeView) Subtract(m Matrix) Matrix {
+	matrix := materialise(s, s.matrix)
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix
+func (s *TransposeView) Negative() Matrix {
+	matrix := materialise(s, s.matrix)
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns returning a copy of the viewed matrix
+func (s *TransposeView) Transpose() Matrix {
+	return s.matrix.Copy()
+}
+
+// Equal the two matrices are equal
+func (s *TransposeView) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *TransposeView) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *TransposeView) Size() int {
+	return s.matrix.Size()
+}
+
+// Values the number of stored elements in the matrix
+func (s *TransposeView) Values() int {
+	return s.matrix.Values()
+}
+
+// NVals the number of stored elements in the matrix
+func (s *TransposeView) NVals() int {
+	return s.matrix.NVals()
+}
+
+// Clear a view is read only and panics
+func (s *TransposeView) Clear() {
+	readOnly(0, 0)
+}
+
+// Enumerate iterates through all stored elements, order is not guaranteed
+func (s *TransposeView) Enumerate() Enumerate {
+	return s.iterator()
+}
+
+func (s *TransposeView) iterator() *viewIterator {
+	iterator := s.matrix.Enumerate()
+	return newViewIterator(func() (int, int, float64, bool) {
+		if !iterator.HasNext() {
+			return 0, 0, 0, false
+		}
+		r, c, value := iterator.Next()
+		return c, r, value, true
+	})
+}
+
+// Map a view is read only, mapping any element panics
+func (s *TransposeView) Map() Map {
+	return s.iterator()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *TransposeView) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
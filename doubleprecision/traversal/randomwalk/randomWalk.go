@@ -0,0 +1,261 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package randomwalk
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+)
+
+// Strategy how the next vertex of a walk is chosen
+type Strategy int
+
+const (
+	// Uniform picks any out-neighbour with equal probability
+	Uniform Strategy = iota
+	// Weighted picks an out-neighbour with probability proportional to the edge weight
+	Weighted
+	// Node2Vec biases the weighted choice by the return parameter p and the in-out parameter q
+	Node2Vec
+)
+
+// ErrInvalidParameter the node2vec return parameter p and in-out parameter q must be positive
+var ErrInvalidParameter = errors.New("randomwalk: p and q must be greater than zero")
+
+// Walker generates seeded random walks from a graph, an edge whose weight is not positive is never followed
+type Walker struct {
+	strategy   Strategy
+	p          float64
+	q          float64
+	seed       int64
+	workers    int
+	neighbours [][]int
+	weights    [][]float64
+	cumulative [][]float64
+}
+
+// NewUniformWalker returns a Walker that ignores edge weights
+func NewUniformWalker(a *doubleprecision.CSRMatrix, seed int64) *Walker {
+	return newWalker(a, Uniform, 1, 1, seed)
+}
+
+// NewWeightedWalker returns a Walker that follows edges in proportion to their weight
+func NewWeightedWalker(a *doubleprecision.CSRMatrix, seed int64) *Walker {
+	return newWalker(a, Weighted, 1, 1, seed)
+}
+
+// NewNode2VecWalker returns a second order Walker
+// a low p keeps the walk close to where it started (breadth-first like) and a low q pushes it outwards (depth-first like)
+func NewNode2VecWalker(a *doubleprecision.CSRMatrix, p, q float64, seed int64) (*Walker, error) {
+	if !(p > 0) || !(q > 0) {
+		return nil, ErrInvalidParameter
+	}
+	return newWalker(a, Node2Vec, p, q, seed), nil
+}
+
+func newWalker(a *doubleprecision.CSRMatrix, strategy Strategy, p, q float64, seed int64) *Walker {
+	n := a.Rows()
+	s := &Walker{
+		strategy:   strategy,
+		p:          p,
+		q:          q,
+		seed:       seed,
+		workers:    runtime.NumCPU(),
+		neighbours: make([][]int, n),
+		weights:    make([][]float64, n),
+		cumulative: make([][]float64, n),
+	}
+
+	for r := 0; r < n; r++ {
+		total := 0.0
+		for iterator := a.RowsAt(r).Enumerate(); iterator.HasNext(); {
+			c, _, value := iterator.Next()
+			if !(value > 0) {
+				continue
+			}
+			total += value
+			s.neighbours[r] = append(s.neighbours[r], c)
+			s.weights[r] = append(s.weights[r], value)
+			s.cumulative[r] = append(s.cumulative[r], total)
+		}
+	}
+
+	return s
+}
+
+// SetWorkers sets the number of goroutines walking in parallel, defaults to the number of CPUs
+func (s *Walker) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	s.workers = workers
+}
+
+// Walk returns a single walk of at most length vertices beginning at start, the walk stops early at a vertex with no out-edges
+func (s *Walker) Walk(start, length int, random *rand.Rand) []int {
+	if length < 1 {
+		return []int{}
+	}
+
+	walk := make([]int, 1, length)
+	walk[0] = start
+
+	for len(walk) < length {
+		current := walk[len(walk)-1]
+		if len(s.neighbours[current]) == 0 {
+			break
+		}
+
+		var next int
+		switch {
+		case s.strategy == Uniform:
+			next = s.neighbours[current][random.Intn(len(s.neighbours[current]))]
+		case s.strategy == Weighted || len(walk) == 1:
+			next = s.weighted(current, random)
+		default:
+			next = s.biased(walk[len(walk)-2], current, random)
+		}
+
+		walk = append(walk, next)
+	}
+
+	return walk
+}
+
+func (s *Walker) weighted(current int, random *rand.Rand) int {
+	cumulative := s.cumulative[current]
+	target := random.Float64() * cumulative[len(cumulative)-1]
+	i := sort.SearchFloat64s(cumulative, target)
+	if i == len(cumulative) {
+		i--
+	}
+	return s.neighbours[current][i]
+}
+
+// biased picks the next vertex using the node2vec transition probabilities
+func (s *Walker) biased(previous, current int, random *rand.Rand) int {
+	neighbours := s.neighbours[current]
+	cumulative := make([]float64, len(neighbours))
+	total := 0.0
+	for i, x := range neighbours {
+		alpha := 1 / s.q
+		if x == previous {
+			alpha = 1 / s.p
+		} else if s.adjacent(previous, x) {
+			alpha = 1
+		}
+		total += alpha * s.weights[current][i]
+		cumulative[i] = total
+	}
+
+	i := sort.SearchFloat64s(cumulative, random.Float64()*total)
+	if i == len(cumulative) {
+		i--
+	}
+	return neighbours[i]
+}
+
+func (s *Walker) adjacent(r, c int) bool {
+	neighbours := s.neighbours[r]
+	i := sort.SearchInts(neighbours, c)
+	return i < len(neighbours) && neighbours[i] == c
+}
+
+// random returns the source for the walk numbered round from start, so results are the same however the work is scheduled
+func (s *Walker) random(round, start int) *rand.Rand {
+	n := int64(len(s.neighbours))
+	return rand.New(rand.NewSource(s.seed + int64(round)*n + int64(start)))
+}
+
+// Stream emits walksPerVertex walks of at most length vertices from every vertex, the channel is closed when all walks are sent or the context is done
+// walks are emitted in any order
+func (s *Walker) Stream(ctx context.Context, length, walksPerVertex int) <-chan []int {
+	out := make(chan []int)
+	n := len(s.neighbours)
+	starts := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < s.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				for round := 0; round < walksPerVertex; round++ {
+					walk := s.Walk(start, length, s.random(round, start))
+					select {
+					case <-ctx.Done():
+						return
+					case out <- walk:
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(starts)
+		for start := 0; start < n; start++ {
+			select {
+			case <-ctx.Done():
+				return
+			case starts <- start:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// Walks returns walksPerVertex walks of at most length vertices from every vertex
+// the walks are ordered by round then start vertex, walks[round*n+start]
+// if the context is done before every walk is generated no walks are returned and the context error is returned
+func (s *Walker) Walks(ctx context.Context, length, walksPerVertex int) ([][]int, error) {
+	n := len(s.neighbours)
+	walks := make([][]int, n*walksPerVertex)
+	starts := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < s.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				for round := 0; round < walksPerVertex; round++ {
+					walks[round*n+start] = s.Walk(start, length, s.random(round, start))
+				}
+			}
+		}()
+	}
+
+	for start := 0; start < n; start++ {
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case starts <- start:
+				continue
+			}
+		}
+
+		close(starts)
+		wg.Wait()
+		return nil, ctx.Err()
+	}
+	close(starts)
+	wg.Wait()
+
+	return walks, nil
+}
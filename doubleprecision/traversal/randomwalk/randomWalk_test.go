@@ -0,0 +1,200 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package randomwalk_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/rossmerr/graphblas/doubleprecision"
+	"github.com/rossmerr/graphblas/doubleprecision/traversal/randomwalk"
+)
+
+func graph() *doubleprecision.CSRMatrix {
+	array := [][]float64{
+		[]float64{0, 1, 0, 1, 0},
+		[]float64{1, 0, 1, 0, 0},
+		[]float64{0, 1, 0, 1, 0},
+		[]float64{1, 0, 1, 0, 5},
+		[]float64{0, 0, 0, 1, 0},
+	}
+	return doubleprecision.NewCSRMatrixFromArray(array)
+}
+
+func TestWalks(t *testing.T) {
+	g := graph()
+
+	node2vec, err := randomwalk.NewNode2VecWalker(g, 0.5, 2, 42)
+	if err != nil {
+		t.Fatalf("NewNode2VecWalker error = %+v", err)
+	}
+
+	tests := []struct {
+		name   string
+		walker *randomwalk.Walker
+	}{
+		{
+			name:   "Uniform",
+			walker: randomwalk.NewUniformWalker(g, 42),
+		},
+		{
+			name:   "Weighted",
+			walker: randomwalk.NewWeightedWalker(g, 42),
+		},
+		{
+			name:   "Node2Vec",
+			walker: node2vec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walks, err := tt.walker.Walks(context.Background(), 10, 3)
+			if err != nil {
+				t.Fatalf("%+v Walks error = %+v", tt.name, err)
+			}
+			if len(walks) != 15 {
+				t.Fatalf("%+v Walks = %+v, want %+v", tt.name, len(walks), 15)
+			}
+
+			for i, walk := range walks {
+				if walk[0] != i%5 {
+					t.Errorf("%+v walk %+v starts at %+v, want %+v", tt.name, i, walk[0], i%5)
+				}
+				if len(walk) != 10 {
+					t.Errorf("%+v walk %+v length %+v, want %+v", tt.name, i, len(walk), 10)
+				}
+				for k := 1; k < len(walk); k++ {
+					if g.At(walk[k-1], walk[k]) == 0 {
+						t.Errorf("%+v walk %+v follows a missing edge %+v -> %+v", tt.name, i, walk[k-1], walk[k])
+					}
+				}
+			}
+
+			tt.walker.SetWorkers(1)
+			again, _ := tt.walker.Walks(context.Background(), 10, 3)
+			if !reflect.DeepEqual(walks, again) {
+				t.Errorf("%+v Walks are not reproducible for the same seed", tt.name)
+			}
+		})
+	}
+}
+
+func TestWalk_Dangling(t *testing.T) {
+	array := [][]float64{
+		[]float64{0, 1},
+		[]float64{0, 0},
+	}
+	g := doubleprecision.NewCSRMatrixFromArray(array)
+
+	walks, _ := randomwalk.NewUniformWalker(g, 1).Walks(context.Background(), 5, 1)
+	want := [][]int{[]int{0, 1}, []int{1}}
+	if !reflect.DeepEqual(walks, want) {
+		t.Errorf("Walks = %+v, want %+v", walks, want)
+	}
+}
+
+func TestWalks_NonPositiveWeights(t *testing.T) {
+	// the stored zero and negative weights from 0 are not edges to follow
+	g := doubleprecision.NewCSRMatrix(4, 4)
+	g.Set(0, 1, 0)
+	g.Set(0, 2, 1)
+	g.Set(0, 3, -1)
+
+	node2vec, err := randomwalk.NewNode2VecWalker(g, 0.5, 2, 1)
+	if err != nil {
+		t.Fatalf("NewNode2VecWalker error = %+v", err)
+	}
+
+	for _, walker := range []*randomwalk.Walker{
+		randomwalk.NewUniformWalker(g, 1),
+		randomwalk.NewWeightedWalker(g, 1),
+		node2vec,
+	} {
+		walks, _ := walker.Walks(context.Background(), 3, 20)
+		for _, walk := range walks {
+			if walk[0] == 0 && !reflect.DeepEqual(walk, []int{0, 2}) {
+				t.Errorf("Walk = %+v, want %+v", walk, []int{0, 2})
+			}
+		}
+	}
+}
+
+func TestNode2Vec_Return(t *testing.T) {
+	array := [][]float64{
+		[]float64{0, 1, 0},
+		[]float64{1, 0, 1},
+		[]float64{0, 1, 0},
+	}
+	g := doubleprecision.NewCSRMatrixFromArray(array)
+
+	walker, err := randomwalk.NewNode2VecWalker(g, 1e-9, 1e9, 7)
+	if err != nil {
+		t.Fatalf("NewNode2VecWalker error = %+v", err)
+	}
+
+	walks, _ := walker.Walks(context.Background(), 3, 10)
+	for _, walk := range walks {
+		if walk[2] != walk[0] {
+			t.Errorf("Walk = %+v, want a return to %+v", walk, walk[0])
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	g := graph()
+	walker := randomwalk.NewWeightedWalker(g, 3)
+
+	count := 0
+	for walk := range walker.Stream(context.Background(), 4, 2) {
+		if len(walk) != 4 {
+			t.Errorf("Stream walk length %+v, want %+v", len(walk), 4)
+		}
+		count++
+	}
+
+	if count != 10 {
+		t.Errorf("Stream = %+v walks, want %+v", count, 10)
+	}
+}
+
+func TestNewNode2VecWalker_Invalid(t *testing.T) {
+	g := graph()
+
+	tests := []struct {
+		name string
+		p    float64
+		q    float64
+	}{
+		{
+			name: "p zero",
+			p:    0,
+			q:    1,
+		},
+		{
+			name: "q negative",
+			p:    1,
+			q:    -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := randomwalk.NewNode2VecWalker(g, tt.p, tt.q, 1); err != randomwalk.ErrInvalidParameter {
+				t.Errorf("%+v NewNode2VecWalker error = %+v, want %+v", tt.name, err, randomwalk.ErrInvalidParameter)
+			}
+		})
+	}
+}
+
+func TestWalks_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	walks, err := randomwalk.NewUniformWalker(graph(), 1).Walks(ctx, 5, 1)
+	if err != context.Canceled || walks != nil {
+		t.Errorf("Walks = %+v, %+v, want %+v, %+v", walks, err, nil, context.Canceled)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"log"
+	"reflect"
+)
+
+func init() {
+	RegisterMatrix(reflect.TypeOf((*TriangularMatrix)(nil)).Elem())
+}
+
+// TriangularMatrix a matrix with all elements either below (upper) or above (lower) the diagonal zero,
+// a unit triangular matrix has ones on the diagonal which are implied and not stored
+type TriangularMatrix struct {
+	r        int // number of rows in the matrix
+	c        int // number of columns in the matrix
+	triangle Triangle
+	unit     bool
+	elements *CSRMatrix
+}
+
+// NewTriangularMatrix returns a TriangularMatrix
+func NewTriangularMatrix(r, c int, triangle Triangle, unit bool) *TriangularMatrix {
+	return &TriangularMatrix{r: r, c: c, triangle: triangle, unit: unit, elements: NewCSRMatrix(r, c)}
+}
+
+// NewTriangularMatrixFromArray returns a TriangularMatrix, only the triangle of the data is read
+func NewTriangularMatrixFromArray(data [][]float64, triangle Triangle, unit bool) *TriangularMatrix {
+	r := len(data)
+	c := 0
+	if r > 0 {
+		c = len(data[0])
+	}
+
+	s := NewTriangularMatrix(r, c, triangle, unit)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			if data[i][k] != 0 && s.inTriangle(i, k) && !(unit && i == k) {
+				s.elements.Set(i, k, data[i][k])
+			}
+		}
+	}
+	return s
+}
+
+// Triangle the triangle of the matrix which can be non zero
+func (s *TriangularMatrix) Triangle() Triangle {
+	return s.triangle
+}
+
+// Unit the diagonal of the matrix is all ones
+func (s *TriangularMatrix) Unit() bool {
+	return s.unit
+}
+
+// inTriangle the element at r-th, c-th is in the triangle of the matrix
+func (s *TriangularMatrix) inTriangle(r, c int) bool {
+	if s.triangle == Lower {
+		return c <= r
+	}
+	return c >= r
+}
+
+// Columns the number of columns of the matrix
+func (s *TriangularMatrix) Columns() int {
+	return s.c
+}
+
+// Rows the number of rows of the matrix
+func (s *TriangularMatrix) Rows() int {
+	return s.r
+}
+
+// Update does a At and Set on the matrix element at r-th, c-th
+func (s *TriangularMatrix) Update(r, c int, f func(float64) float64) {
+	if !s.inTriangle(r, c) {
+		log.Panicf("Element '%+v, %+v' is outside the triangle", r, c)
+	}
+
+	if s.unit && r == c {
+		log.Panicf("Element '%+v, %+v' is on the unit diagonal", r, c)
+	}
+
+	s.elements.Update(r, c, f)
+}
+
+// At returns the value of a matrix element at r-th, c-th
+func (s *TriangularMatrix) At(r, c int) float64 {
+	if s.unit && r == c {
+		if r < 0 || r >= s.r || c >= s.c {
+			log.Panicf("Row '%+v' is invalid", r)
+		}
+		return 1
+	}
+	return s.elements.At(r, c)
+}
+
+// Has the element at r-th, c-th is stored, the unit diagonal is always stored
+func (s *TriangularMatrix) Has(r, c int) bool {
+	if s.unit && r == c {
+		return true
+	}
+	return s.elements.Has(r, c)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *TriangularMatrix) Remove(r, c int) {
+	if s.unit && r == c {
+		log.Panicf("Element '%+v, %+v' is on the unit diagonal", r, c)
+	}
+	s.elements.Remove(r, c)
+}
+
+// Set sets the value at r-th, c-th of the matrix
+func (s *TriangularMatrix) Set(r, c int, value float64) {
+	s.Update(r, c, func(v float64) float64 {
+		return value
+	})
+}
+
+// ColumnsAt return the columns at c-th
+func (s *TriangularMatrix) ColumnsAt(c int) Vector {
+	columns := s.elements.ColumnsAt(c)
+	if s.unit && c < s.r {
+		columns.SetVec(c, 1)
+	}
+	return columns
+}
+
+// RowsAt return the rows at r-th
+func (s *TriangularMatrix) RowsAt(r int) Vector {
+	rows := s.elements.RowsAt(r)
+	if s.unit && r < s.c {
+		rows.SetVec(r, 1)
+	}
+	return rows
+}
+
+// RowsAtToArray return the rows at r-th
+func (s *TriangularMatrix) RowsAtToArray(r int) []float64 {
+	rows := s.elements.RowsAtToArray(r)
+	if s.unit && r < s.c {
+		rows[r] = 1
+	}
+	return rows
+}
+
+// Copy copies the matrix
+func (s *TriangularMatrix) Copy() Matrix {
+	return &TriangularMatrix{
+		r:        s.r,
+		c:        s.c,
+		triangle: s.triangle,
+		unit:     s.unit,
+		elements: s.elements.Copy().(*CSRMatrix),
+	}
+}
+
+// Scalar multiplication of a matrix by alpha
+func (s *TriangularMatrix) Scalar(alpha float64) Matrix {
+	return Scalar(context.Background(), newCSRMatrixFromMatrix(s), alpha)
+}
+
+// Multiply multiplies a matrix by another matrix
+func (s *TriangularMatrix) Multiply(m Matrix) Matrix {
+	matrix := NewCSRMatrix(s.Rows(), m.Columns())
+	MatrixMatrixMultiply(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Add addition of a matrix by another matrix, the result is a CSRMatrix
+func (s *TriangularMatrix) Add(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Add(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Subtract subtracts one matrix from another matrix, the result is a CSRMatrix
+func (s *TriangularMatrix) Subtract(m Matrix) Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Subtract(context.Background(), s, m, nil, matrix)
+	return matrix
+}
+
+// Negative the negative of a matrix, the result is a CSRMatrix
+func (s *TriangularMatrix) Negative() Matrix {
+	matrix := newCSRMatrixFromMatrix(s)
+	Negative(context.Background(), s, nil, matrix)
+	return matrix
+}
+
+// Transpose swaps the rows and columns, the lower triangle becomes the upper triangle
+func (s *TriangularMatrix) Transpose() Matrix {
+	triangle := Upper
+	if s.triangle == Upper {
+		triangle = Lower
+	}
+
+	return &TriangularMatrix{
+		r:        s.c,
+		c:        s.r,
+		triangle: triangle,
+		unit:     s.unit,
+		elements: s.elements.Transpose().(*CSRMatrix),
+	}
+}
+
+// Equal the two matrices are equal
+func (s *TriangularMatrix) Equal(m Matrix) bool {
+	return Equal(context.Background(), s, m)
+}
+
+// NotEqual the two matrices are not equal
+func (s *TriangularMatrix) NotEqual(m Matrix) bool {
+	return NotEqual(context.Background(), s, m)
+}
+
+// Size of the matrix
+func (s *TriangularMatrix) Size() int {
+	return s.r * s.c
+}
+
+// Values the number of stored elements in the matrix including the unit diagonal
+func (s *TriangularMatrix) Values() int {
+	return s.NVals()
+}
+
+// NVals the number of stored elements in the matrix including the unit diagonal
+func (s *TriangularMatrix) NVals() int {
+	return s.elements.NVals() + s.diagonal()
+}
+
+// diagonal the length of the unit diagonal
+func (s *TriangularMatrix) diagonal() int {
+	if !s.unit {
+		return 0
+	}
+	if s.r < s.c {
+		return s.r
+	}
+	return s.c
+}
+
+// Clear removes all elements from a matrix
+func (s *TriangularMatrix) Clear() {
+	s.elements.Clear()
+}
+
+// Enumerate iterates through all stored elements including the unit diagonal, order is not guaranteed
+func (s *TriangularMatrix) Enumerate() Enumerate {
+	return &triangularMatrixIterator{
+		iterator: s.elements.iterator(),
+		diagonal: s.diagonal(),
+	}
+}
+
+type triangularMatrixIterator struct {
+	iterator *cSRMatrixIterator
+	diagonal int
+	index    int
+}
+
+// HasNext checks the iterator has any more values
+func (s *triangularMatrixIterator) HasNext() bool {
+	return s.index < s.diagonal || s.iterator.HasNext()
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *triangularMatrixIterator) Next() (int, int, float64) {
+	if s.index < s.diagonal {
+		s.index++
+		return s.index - 1, s.index - 1, 1
+	}
+	return s.iterator.Next()
+}
+
+// Map replace each stored element with the result of applying a function to its value,
+// the unit diagonal is not visited
+func (s *TriangularMatrix) Map() Map {
+	return s.elements.Map()
+}
+
+// Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
+func (s *TriangularMatrix) Element(r, c int) bool {
+	return s.At(r, c) > 0
+}
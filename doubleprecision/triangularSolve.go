@@ -0,0 +1,328 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime"
+	"sync"
+)
+
+// ErrZeroDiagonal a triangular matrix has a zero on its diagonal so the system can not be solved
+var ErrZeroDiagonal = errors.New("doubleprecision: zero on the diagonal of a triangular matrix")
+
+// Triangle the part of a matrix to use
+type Triangle int
+
+const (
+	// Lower triangle, elements above the diagonal are ignored
+	Lower Triangle = iota
+	// Upper triangle, elements below the diagonal are ignored
+	Upper
+)
+
+// triangularRows the triangle of a matrix by rows without its diagonal
+type triangularRows struct {
+	n        int
+	cols     [][]int
+	values   [][]float64
+	diagonal []float64
+}
+
+func newTriangularRows(a Matrix, triangle Triangle, unit bool) *triangularRows {
+	n := a.Rows()
+	s := &triangularRows{
+		n:        n,
+		cols:     make([][]int, n),
+		values:   make([][]float64, n),
+		diagonal: make([]float64, n),
+	}
+
+	keep := func(r, c int) bool {
+		if triangle == Lower {
+			return c < r
+		}
+		return c > r
+	}
+
+	add := func(r, c int, value float64) {
+		if r == c {
+			s.diagonal[r] = value
+		} else if keep(r, c) && value != 0 {
+			s.cols[r] = append(s.cols[r], c)
+			s.values[r] = append(s.values[r], value)
+		}
+	}
+
+	switch m := a.(type) {
+	case *CSRMatrix:
+		for r := 0; r < n; r++ {
+			for i := m.rowStart[r]; i < m.rowStart[r+1]; i++ {
+				add(r, m.cols[i], m.values[i])
+			}
+		}
+	case *CSCMatrix:
+		for c := 0; c < m.c; c++ {
+			for i := m.colStart[c]; i < m.colStart[c+1]; i++ {
+				add(m.rows[i], c, m.values[i])
+			}
+		}
+	default:
+		for iterator := a.Enumerate(); iterator.HasNext(); {
+			r, c, value := iterator.Next()
+			add(r, c, value)
+		}
+	}
+
+	if unit {
+		for i := range s.diagonal {
+			s.diagonal[i] = 1
+		}
+	}
+
+	return s
+}
+
+// solveRow x_r = (b_r - Σ a_rc x_c) / a_rr for every right hand side, x_r is stored when b_r or one of the x_c is stored
+func (s *triangularRows) solveRow(r int, x [][]float64, stored [][]bool) {
+	for j, column := range x {
+		sum := column[r]
+		reached := stored[j][r]
+		for i, c := range s.cols[r] {
+			if stored[j][c] {
+				sum -= s.values[r][i] * column[c]
+				reached = true
+			}
+		}
+		column[r] = sum / s.diagonal[r]
+		stored[j][r] = reached
+	}
+}
+
+// levels groups the rows so every row only depends on rows in earlier levels
+func (s *triangularRows) levels(triangle Triangle) [][]int {
+	level := make([]int, s.n)
+	levels := [][]int{}
+
+	visit := func(r int) {
+		l := 0
+		for _, c := range s.cols[r] {
+			if level[c]+1 > l {
+				l = level[c] + 1
+			}
+		}
+		level[r] = l
+		if l == len(levels) {
+			levels = append(levels, []int{})
+		}
+		levels[l] = append(levels[l], r)
+	}
+
+	if triangle == Lower {
+		for r := 0; r < s.n; r++ {
+			visit(r)
+		}
+	} else {
+		for r := s.n - 1; r >= 0; r-- {
+			visit(r)
+		}
+	}
+
+	return levels
+}
+
+func triangularSolveSetup(a Matrix, b Matrix, x Matrix) {
+	if a.Rows() != a.Columns() {
+		log.Panicf("Can not solve a non square matrix %+v, %+v", a.Rows(), a.Columns())
+	}
+
+	if b.Rows() != a.Rows() {
+		log.Panicf("Can not solve found rows mismatch %+v, %+v", a.Rows(), b.Rows())
+	}
+
+	if x.Rows() != b.Rows() || x.Columns() != b.Columns() {
+		log.Panicf("Can not solve found output size mismatch %+v×%+v, %+v×%+v", x.Rows(), x.Columns(), b.Rows(), b.Columns())
+	}
+}
+
+// triangularColumns copies the right hand sides and their stored elements by column
+func triangularColumns(b Matrix) ([][]float64, [][]bool) {
+	x := make([][]float64, b.Columns())
+	stored := make([][]bool, b.Columns())
+	for c := range x {
+		x[c] = make([]float64, b.Rows())
+		stored[c] = make([]bool, b.Rows())
+	}
+	for iterator := b.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		x[c][r] = value
+		stored[c][r] = true
+	}
+	return x, stored
+}
+
+// triangularStore writes the reached elements, an element the solve never reached is removed
+func triangularStore(x [][]float64, stored [][]bool, matrix Matrix) {
+	for c, column := range x {
+		for r, value := range column {
+			if stored[c][r] {
+				matrix.Set(r, c, value)
+			} else if matrix.Has(r, c) {
+				matrix.Remove(r, c)
+			}
+		}
+	}
+}
+
+// TriangularSolve forward or backward substitution for every column of B
+//  AX = B
+// only the given triangle of A is read, with unit the diagonal is taken to be one,
+// an element of X is only stored when it is reached from a stored element of B
+func TriangularSolve(ctx context.Context, a Matrix, triangle Triangle, unit bool, b Matrix, matrix Matrix) error {
+	triangularSolveSetup(a, b, matrix)
+
+	x, stored := triangularColumns(b)
+
+	// a compressed column matrix is solved column oriented straight from its storage
+	if csc, ok := a.(*CSCMatrix); ok {
+		if err := triangularSolveCSC(ctx, csc, triangle, unit, x, stored); err != nil {
+			return err
+		}
+		triangularStore(x, stored, matrix)
+		return nil
+	}
+
+	s := newTriangularRows(a, triangle, unit)
+	for _, d := range s.diagonal {
+		if d == 0 {
+			return ErrZeroDiagonal
+		}
+	}
+
+	for i := 0; i < s.n; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		r := i
+		if triangle == Upper {
+			r = s.n - 1 - i
+		}
+		s.solveRow(r, x, stored)
+	}
+
+	triangularStore(x, stored, matrix)
+	return nil
+}
+
+func triangularSolveCSC(ctx context.Context, a *CSCMatrix, triangle Triangle, unit bool, x [][]float64, stored [][]bool) error {
+	n := a.c
+	diagonal := make([]float64, n)
+	for c := 0; c < n; c++ {
+		diagonal[c] = 1
+		if unit {
+			continue
+		}
+		diagonal[c] = 0
+		for i := a.colStart[c]; i < a.colStart[c+1]; i++ {
+			if a.rows[i] == c {
+				diagonal[c] = a.values[i]
+			}
+		}
+		if diagonal[c] == 0 {
+			return ErrZeroDiagonal
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		c := k
+		if triangle == Upper {
+			c = n - 1 - k
+		}
+
+		for j, column := range x {
+			if !stored[j][c] {
+				continue
+			}
+
+			column[c] /= diagonal[c]
+			value := column[c]
+			for i := a.colStart[c]; i < a.colStart[c+1]; i++ {
+				r := a.rows[i]
+				if (triangle == Lower && r > c) || (triangle == Upper && r < c) {
+					column[r] -= a.values[i] * value
+					stored[j][r] = true
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// TriangularSolveParallel level-scheduled forward or backward substitution for every column of B
+//  AX = B
+// rows whose dependencies are all solved form a level and are solved in parallel, one level after another,
+// an element of X is only stored when it is reached from a stored element of B
+func TriangularSolveParallel(ctx context.Context, a Matrix, triangle Triangle, unit bool, b Matrix, matrix Matrix) error {
+	triangularSolveSetup(a, b, matrix)
+
+	s := newTriangularRows(a, triangle, unit)
+	for _, d := range s.diagonal {
+		if d == 0 {
+			return ErrZeroDiagonal
+		}
+	}
+
+	x, stored := triangularColumns(b)
+	workers := runtime.NumCPU()
+
+	for _, level := range s.levels(triangle) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if len(level) < 2*workers {
+			for _, r := range level {
+				s.solveRow(r, x, stored)
+			}
+			continue
+		}
+
+		var wg sync.WaitGroup
+		size := (len(level) + workers - 1) / workers
+		for start := 0; start < len(level); start += size {
+			end := start + size
+			if end > len(level) {
+				end = len(level)
+			}
+
+			wg.Add(1)
+			go func(rows []int) {
+				defer wg.Done()
+				for _, r := range rows {
+					s.solveRow(r, x, stored)
+				}
+			}(level[start:end])
+		}
+		wg.Wait()
+	}
+
+	triangularStore(x, stored, matrix)
+	return nil
+}
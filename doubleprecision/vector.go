@@ -15,6 +15,12 @@ type Vector interface {
 	// SetVec sets the value at i-th of the vector
 	SetVec(i int, value float64)
 
+	// HasVec the element at i-th is stored
+	HasVec(i int) bool
+
+	// RemoveVec removes the stored element at i-th
+	RemoveVec(i int)
+
 	// Length of the vector
 	Length() int
 }
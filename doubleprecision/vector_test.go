@@ -486,7 +486,7 @@ func TestVector_Size(t *testing.T) {
 		{
 			name: "SparseVector",
 			s:    doubleprecision.NewSparseVector(6),
-			size: 5,
+			size: 6,
 		},
 	}
 	for _, tt := range tests {
@@ -499,3 +499,36 @@ func TestVector_Size(t *testing.T) {
 		})
 	}
 }
+
+func TestVector_AtVec_Sparse(t *testing.T) {
+
+	setup := func(m doubleprecision.Vector) {
+		m.SetVec(4, 6)
+		m.SetVec(1, 2)
+	}
+
+	tests := []struct {
+		name string
+		s    doubleprecision.Vector
+	}{
+		{
+			name: "DenseVector",
+			s:    doubleprecision.NewDenseVector(6),
+		},
+		{
+			name: "SparseVector",
+			s:    doubleprecision.NewSparseVector(6),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup(tt.s)
+			if got := tt.s.AtVec(4); got != 6 {
+				t.Errorf("%+v AtVec = %+v, want %+v", tt.name, got, 6)
+			}
+			if got := tt.s.AtVec(1); got != 2 {
+				t.Errorf("%+v AtVec = %+v, want %+v", tt.name, got, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package doubleprecision
+
+import "log"
+
+// readOnly panics as a view can not be modified, changes must be made to the matrix it views
+func readOnly(r, c int) {
+	log.Panicf("Can not modify element '%+v, %+v' of a read only view", r, c)
+}
+
+// materialise copies the elements of a view into a new matrix,
+// a DenseMatrix when the viewed matrix is dense otherwise a CSRMatrix
+func materialise(s, source Matrix) Matrix {
+	if IsSparseMatrix(source) {
+		return newCSRMatrixFromMatrix(s)
+	}
+
+	matrix := NewDenseMatrix(s.Rows(), s.Columns())
+	for iterator := s.Enumerate(); iterator.HasNext(); {
+		r, c, value := iterator.Next()
+		matrix.Set(r, c, value)
+	}
+	return matrix
+}
+
+// newMaterialised a new empty matrix in the format materialise would return
+func newMaterialised(r, c int, source Matrix) Matrix {
+	if IsSparseMatrix(source) {
+		return NewCSRMatrix(r, c)
+	}
+	return NewDenseMatrix(r, c)
+}
+
+// viewIterator iterates the elements yielded by next until it returns false
+type viewIterator struct {
+	next  func() (int, int, float64, bool)
+	r     int
+	c     int
+	value float64
+	ok    bool
+}
+
+func newViewIterator(next func() (int, int, float64, bool)) *viewIterator {
+	i := &viewIterator{next: next}
+	i.r, i.c, i.value, i.ok = next()
+	return i
+}
+
+// HasNext checks the iterator has any more values
+func (s *viewIterator) HasNext() bool {
+	return s.ok
+}
+
+// Next moves the iterator and returns the row, column and value
+func (s *viewIterator) Next() (int, int, float64) {
+	r, c, value := s.r, s.c, s.value
+	s.r, s.c, s.value, s.ok = s.next()
+	return r, c, value
+}
+
+// Map a view is read only so mapping any element panics
+func (s *viewIterator) Map(f func(int, int, float64) float64) {
+	readOnly(s.r, s.c)
+}
+
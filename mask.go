@@ -42,3 +42,28 @@ func (s *EmptyMask) Rows() int {
 func (s *EmptyMask) Element(r, c int) bool {
 	return false
 }
+
+// ComplementMask is a mask with the elements of another mask inverted
+type ComplementMask struct {
+	mask Mask
+}
+
+// NewComplementMask returns a ComplementMask
+func NewComplementMask(mask Mask) *ComplementMask {
+	return &ComplementMask{mask: mask}
+}
+
+// Columns the number of columns of the mask
+func (s *ComplementMask) Columns() int {
+	return s.mask.Columns()
+}
+
+// Rows the number of rows of the mask
+func (s *ComplementMask) Rows() int {
+	return s.mask.Rows()
+}
+
+// Element of the mask is true when the element of the inverted mask is false
+func (s *ComplementMask) Element(r, c int) bool {
+	return !s.mask.Element(r, c)
+}
@@ -101,6 +101,32 @@ func (s *CSCMatrix) At(r, c int) (value float32) {
 	return
 }
 
+func (s *CSCMatrix) pointer(r, c int) (int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointerStart, pointerEnd := s.rowIndex(r, c)
+	return pointerStart, pointerStart < pointerEnd && s.rows[pointerStart] == r
+}
+
+// Has the element at r-th, c-th is stored
+func (s *CSCMatrix) Has(r, c int) bool {
+	_, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *CSCMatrix) Remove(r, c int) {
+	if pointer, found := s.pointer(r, c); found {
+		s.remove(pointer, c)
+	}
+}
+
 // Set sets the value at r-th, c-th of the matrix
 func (s *CSCMatrix) Set(r, c int, value float32) {
 	s.Update(r, c, func(v float32) float32 {
@@ -288,6 +314,11 @@ func (s *CSCMatrix) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the matrix
+func (s *CSCMatrix) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a matrix
 func (s *CSCMatrix) Clear() {
 	s.values = make([]float32, 0)
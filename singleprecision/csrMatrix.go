@@ -97,6 +97,32 @@ func (s *CSRMatrix) At(r, c int) (value float32) {
 	return
 }
 
+func (s *CSRMatrix) pointer(r, c int) (int, bool) {
+	if r < 0 || r >= s.r {
+		log.Panicf("Row '%+v' is invalid", r)
+	}
+
+	if c < 0 || c >= s.c {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	pointerStart, pointerEnd := s.columnIndex(r, c)
+	return pointerStart, pointerStart < pointerEnd && s.cols[pointerStart] == c
+}
+
+// Has the element at r-th, c-th is stored
+func (s *CSRMatrix) Has(r, c int) bool {
+	_, found := s.pointer(r, c)
+	return found
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *CSRMatrix) Remove(r, c int) {
+	if pointer, found := s.pointer(r, c); found {
+		s.remove(pointer, r)
+	}
+}
+
 // Set sets the value at r-th, c-th of the matrix
 func (s *CSRMatrix) Set(r, c int, value float32) {
 	s.Update(r, c, func(v float32) float32 {
@@ -284,6 +310,11 @@ func (s *CSRMatrix) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the matrix
+func (s *CSRMatrix) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a matrix
 func (s *CSRMatrix) Clear() {
 	s.values = make([]float32, 0)
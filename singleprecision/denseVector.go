@@ -10,10 +10,13 @@ import (
 	"log"
 )
 
-// DenseVector a vector
+// DenseVector a vector, the storage is full and a bitset marks the stored elements,
+// a new vector stores every element and Remove or Clear leave a missing element as zero
 type DenseVector struct {
-	l      int // length of the sparse vector
-	values []float32
+	l       int // length of the sparse vector
+	values  []float32
+	present []uint64 // the stored elements, nil when every element is stored
+	count   int      // number of stored elements when present is not nil
 }
 
 // NewDenseVector returns a DenseVector
@@ -28,6 +31,10 @@ func NewDenseVectorFromArray(data []float32) *DenseVector {
 	return &DenseVector{l: len(data), values: arr}
 }
 
+func (s *DenseVector) has(i int) bool {
+	return s.present == nil || s.present[i/64]&(1<<uint(i%64)) != 0
+}
+
 // AtVec returns the value of a vector element at i-th
 func (s *DenseVector) AtVec(i int) float32 {
 	if i < 0 || i >= s.Length() {
@@ -44,6 +51,42 @@ func (s *DenseVector) SetVec(i int, value float32) {
 	}
 
 	s.values[i] = value
+	if !s.has(i) {
+		s.present[i/64] |= 1 << uint(i%64)
+		s.count++
+	}
+}
+
+// HasVec the element at i-th is stored
+func (s *DenseVector) HasVec(i int) bool {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	return s.has(i)
+}
+
+// RemoveVec the element at i-th, the missing element reads as zero
+func (s *DenseVector) RemoveVec(i int) {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	if !s.has(i) {
+		return
+	}
+
+	if s.present == nil {
+		s.present = make([]uint64, (s.l+63)/64)
+		for k := 0; k < s.l; k++ {
+			s.present[k/64] |= 1 << uint(k%64)
+		}
+		s.count = s.l
+	}
+
+	s.present[i/64] &^= 1 << uint(i%64)
+	s.count--
+	s.values[i] = 0
 }
 
 // Size of the vector
@@ -85,6 +128,24 @@ func (s *DenseVector) At(r, c int) (value float32) {
 	return s.AtVec(r)
 }
 
+// Has the element at r-th, c-th is stored
+func (s *DenseVector) Has(r, c int) bool {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return s.HasVec(r)
+}
+
+// Remove the element at r-th, c-th, the missing element reads as zero
+func (s *DenseVector) Remove(r, c int) {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	s.RemoveVec(r)
+}
+
 // Set sets the value at r-th, c-th of the vector
 func (s *DenseVector) Set(r, c int, value float32) {
 	if r < 0 || r >= s.Rows() {
@@ -136,10 +197,11 @@ func (s *DenseVector) RowsAtToArray(r int) []float32 {
 }
 
 func (s *DenseVector) copy() *DenseVector {
-	vector := NewDenseVector(s.l)
+	vector := NewDenseVectorFromArray(s.values)
 
-	for i, v := range s.values {
-		vector.SetVec(i, v)
+	if s.present != nil {
+		vector.present = append([]uint64(nil), s.present...)
+		vector.count = s.count
 	}
 
 	return vector
@@ -147,17 +209,7 @@ func (s *DenseVector) copy() *DenseVector {
 
 // Copy copies the vector
 func (s *DenseVector) Copy() Matrix {
-	vector := NewDenseVector(s.l)
-
-	for i, v := range s.values {
-		if v != 0.0 {
-			vector.SetVec(i, v)
-		} else {
-			vector.SetVec(i, v)
-		}
-	}
-
-	return vector
+	return s.copy()
 }
 
 // Scalar multiplication of a vector by alpha
@@ -212,15 +264,25 @@ func (s *DenseVector) NotEqual(m Matrix) bool {
 
 // Values the number of elements in the vector
 func (s *DenseVector) Values() int {
-	return s.l
+	return s.NVals()
+}
+
+// NVals the number of stored elements in the vector
+func (s *DenseVector) NVals() int {
+	if s.present == nil {
+		return s.l
+	}
+	return s.count
 }
 
 // Clear removes all elements from a vector
 func (s *DenseVector) Clear() {
 	s.values = make([]float32, s.l)
+	s.present = make([]uint64, (s.l+63)/64)
+	s.count = 0
 }
 
-// Enumerate iterates through all non-zero elements, order is not guaranteed
+// Enumerate iterates through all stored elements, order is not guaranteed
 func (s *DenseVector) Enumerate() Enumerate {
 	return s.iterator()
 }
@@ -228,7 +290,7 @@ func (s *DenseVector) Enumerate() Enumerate {
 func (s *DenseVector) iterator() *denseVectorIterator {
 	i := &denseVectorIterator{
 		matrix: s,
-		size:   s.Values(),
+		size:   s.l,
 		last:   0,
 		c:      0,
 		r:      0,
@@ -242,11 +304,14 @@ type denseVectorIterator struct {
 	last   int
 	c      int
 	r      int
-	rOld   int
 }
 
 // HasNext checks the iterator has any more values
 func (s *denseVectorIterator) HasNext() bool {
+	for s.last < s.size && !s.matrix.has(s.last) {
+		s.last++
+	}
+
 	if s.last >= s.size {
 		return false
 	}
@@ -254,12 +319,8 @@ func (s *denseVectorIterator) HasNext() bool {
 }
 
 func (s *denseVectorIterator) next() {
-	if s.r == s.matrix.Rows() {
-		s.r = 0
-		s.c++
-	}
-	s.rOld = s.r
-	s.r++
+	s.HasNext()
+	s.r = s.last
 	s.last++
 }
 
@@ -267,7 +328,7 @@ func (s *denseVectorIterator) next() {
 func (s *denseVectorIterator) Next() (int, int, float32) {
 	s.next()
 
-	return s.rOld, 0, s.matrix.AtVec(s.rOld)
+	return s.r, 0, s.matrix.AtVec(s.r)
 }
 
 // Map replace each element with the result of applying a function to its value
@@ -290,7 +351,7 @@ func (s *denseVectorMap) HasNext() bool {
 func (s *denseVectorMap) Map(f func(int, int, float32) float32) {
 	s.next()
 
-	s.matrix.SetVec(s.rOld, f(s.rOld, 0, s.matrix.AtVec(s.rOld)))
+	s.matrix.SetVec(s.r, f(s.r, 0, s.matrix.AtVec(s.r)))
 }
 
 // Element of the mask for each tuple that exists in the matrix for which the value of the tuple cast to Boolean is true
@@ -17,6 +17,12 @@ type Matrix interface {
 	// Set sets the value at r-th, c-th of the matrix
 	Set(r, c int, value float32)
 
+	// Has the element at r-th, c-th is stored
+	Has(r, c int) bool
+
+	// Remove removes the stored element at r-th, c-th
+	Remove(r, c int)
+
 	// Update does a At and Set on the matrix element at r-th, c-th
 	Update(r, c int, f func(float32) float32)
 
@@ -70,6 +76,9 @@ type Matrix interface {
 	// The number of elements in the matrix (non-zero counted for dense matrices)
 	Values() int
 
+	// NVals the number of stored elements in the matrix
+	NVals() int
+
 	// Clear removes all elements from a matrix
 	Clear()
 }
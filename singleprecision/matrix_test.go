@@ -818,3 +818,69 @@ func TestMatrix_FromArray(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrix_Has(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     singlePrecision.Matrix
+		nvals int
+	}{
+		{
+			name:  "DenseMatrix",
+			s:     singlePrecision.NewDenseMatrix(2, 2),
+			nvals: 3,
+		},
+		{
+			name:  "CSCMatrix",
+			s:     singlePrecision.NewCSCMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "CSRMatrix",
+			s:     singlePrecision.NewCSRMatrix(2, 2),
+			nvals: 1,
+		},
+		{
+			name:  "DenseVector",
+			s:     singlePrecision.NewDenseVector(4),
+			nvals: 3,
+		},
+		{
+			name:  "SparseVector",
+			s:     singlePrecision.NewSparseVector(4),
+			nvals: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.s.Set(0, 0, 3)
+			tt.s.Set(1, 0, 5)
+
+			if !tt.s.Has(1, 0) {
+				t.Errorf("%+v Has = %+v, want %+v", tt.name, false, true)
+			}
+
+			tt.s.Remove(1, 0)
+
+			if got := tt.s.At(1, 0); got != 0 {
+				t.Errorf("%+v Remove = %+v, want %+v", tt.name, got, 0)
+			}
+
+			if tt.s.Has(1, 0) {
+				t.Errorf("%+v Has after Remove = %+v, want %+v", tt.name, true, false)
+			}
+
+			if got := tt.s.NVals(); got != tt.nvals {
+				t.Errorf("%+v NVals = %+v, want %+v", tt.name, got, tt.nvals)
+			}
+
+			var count int
+			for iterator := tt.s.Enumerate(); iterator.HasNext(); iterator.Next() {
+				count++
+			}
+			if count != tt.nvals {
+				t.Errorf("%+v Enumerate = %+v, want %+v", tt.name, count, tt.nvals)
+			}
+		})
+	}
+}
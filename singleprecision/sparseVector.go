@@ -82,6 +82,46 @@ func (s *SparseVector) SetVec(i int, value float32) {
 	}
 }
 
+// HasVec the element at i-th is stored
+func (s *SparseVector) HasVec(i int) bool {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	pointer, length, _ := s.index(i)
+	return pointer < length && s.indices[pointer] == i
+}
+
+// RemoveVec removes the stored element at i-th
+func (s *SparseVector) RemoveVec(i int) {
+	if i < 0 || i >= s.Length() {
+		log.Panicf("Length '%+v' is invalid", i)
+	}
+
+	pointer, length, _ := s.index(i)
+	if pointer < length && s.indices[pointer] == i {
+		s.remove(pointer)
+	}
+}
+
+// Has the element at r-th, c-th is stored
+func (s *SparseVector) Has(r, c int) bool {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	return s.HasVec(r)
+}
+
+// Remove removes the stored element at r-th, c-th
+func (s *SparseVector) Remove(r, c int) {
+	if c < 0 || c >= s.Columns() {
+		log.Panicf("Column '%+v' is invalid", c)
+	}
+
+	s.RemoveVec(r)
+}
+
 // Columns the number of columns of the vector
 func (s *SparseVector) Columns() int {
 	return 1
@@ -182,7 +222,7 @@ func (s *SparseVector) remove(pointer int) {
 
 func (s *SparseVector) index(i int) (int, int, error) {
 	length := len(s.indices)
-	if i > length {
+	if length == 0 || i > s.indices[length-1] {
 		return length, length, nil
 	}
 
@@ -279,6 +319,11 @@ func (s *SparseVector) Values() int {
 	return len(s.values)
 }
 
+// NVals the number of stored elements in the vector
+func (s *SparseVector) NVals() int {
+	return len(s.values)
+}
+
 // Clear removes all elements from a vector
 func (s *SparseVector) Clear() {
 	s.values = make([]float32, 0)
@@ -0,0 +1,119 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package float64op
+
+import "github.com/rossmerr/graphblas/unaryop"
+
+// IndexUnaryOpFloat64 is a function that maps the row, column and value of an element to a boolean
+type IndexUnaryOpFloat64 interface {
+	unaryop.UnaryOp
+	Apply(r, c int, value float64) bool
+}
+
+type indexUnaryOpFloat64 struct {
+	apply func(int, int, float64) bool
+}
+
+func (s *indexUnaryOpFloat64) Operator() {}
+func (s *indexUnaryOpFloat64) UnaryOp()  {}
+
+func (s *indexUnaryOpFloat64) Apply(r, c int, value float64) bool {
+	return s.apply(r, c, value)
+}
+
+// NewIndexUnaryOpFloat64 returns a IndexUnaryOpFloat64 of the function
+func NewIndexUnaryOpFloat64(apply func(r, c int, value float64) bool) IndexUnaryOpFloat64 {
+	return &indexUnaryOpFloat64{apply: apply}
+}
+
+// Tril on or below the k-th diagonal f(r, c, x) = c - r <= k
+func Tril(k int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return c-r <= k
+	})
+}
+
+// Triu on or above the k-th diagonal f(r, c, x) = c - r >= k
+func Triu(k int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return c-r >= k
+	})
+}
+
+// Diag on the k-th diagonal f(r, c, x) = c - r == k
+func Diag(k int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return c-r == k
+	})
+}
+
+// OffDiag off the k-th diagonal f(r, c, x) = c - r != k
+func OffDiag(k int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return c-r != k
+	})
+}
+
+// NonZero f(r, c, x) = x != 0
+var NonZero = NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+	return value != 0
+})
+
+// ValueEqual f(r, c, x) = x == y
+func ValueEqual(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value == y
+	})
+}
+
+// ValueNotEqual f(r, c, x) = x != y
+func ValueNotEqual(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value != y
+	})
+}
+
+// ValueGreaterThan f(r, c, x) = x > y
+func ValueGreaterThan(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value > y
+	})
+}
+
+// ValueGreaterThanOrEqual f(r, c, x) = x >= y
+func ValueGreaterThanOrEqual(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value >= y
+	})
+}
+
+// ValueLessThan f(r, c, x) = x < y
+func ValueLessThan(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value < y
+	})
+}
+
+// ValueLessThanOrEqual f(r, c, x) = x <= y
+func ValueLessThanOrEqual(y float64) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return value <= y
+	})
+}
+
+// RowRange f(r, c, x) = begin <= r < end
+func RowRange(begin, end int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return begin <= r && r < end
+	})
+}
+
+// ColumnRange f(r, c, x) = begin <= c < end
+func ColumnRange(begin, end int) IndexUnaryOpFloat64 {
+	return NewIndexUnaryOpFloat64(func(r, c int, value float64) bool {
+		return begin <= c && c < end
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2018 Ross Merrigan
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package float64op_test
+
+import (
+	"testing"
+
+	"github.com/rossmerr/graphblas/unaryop/float64op"
+)
+
+func Test_IndexUnaryOp(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      float64op.IndexUnaryOpFloat64
+		r      int
+		c      int
+		in     float64
+		result bool
+	}{
+		{
+			name:   "Tril",
+			s:      float64op.Tril(0),
+			r:      2,
+			c:      1,
+			result: true,
+		},
+		{
+			name:   "Triu",
+			s:      float64op.Triu(1),
+			r:      1,
+			c:      1,
+			result: false,
+		},
+		{
+			name:   "Diag",
+			s:      float64op.Diag(-1),
+			r:      2,
+			c:      1,
+			result: true,
+		},
+		{
+			name:   "OffDiag",
+			s:      float64op.OffDiag(0),
+			r:      1,
+			c:      1,
+			result: false,
+		},
+		{
+			name:   "NonZero",
+			s:      float64op.NonZero,
+			in:     0,
+			result: false,
+		},
+		{
+			name:   "ValueGreaterThan",
+			s:      float64op.ValueGreaterThan(2),
+			in:     3,
+			result: true,
+		},
+		{
+			name:   "ValueLessThanOrEqual",
+			s:      float64op.ValueLessThanOrEqual(2),
+			in:     3,
+			result: false,
+		},
+		{
+			name:   "RowRange",
+			s:      float64op.RowRange(1, 3),
+			r:      3,
+			result: false,
+		},
+		{
+			name:   "ColumnRange",
+			s:      float64op.ColumnRange(1, 3),
+			c:      1,
+			result: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.s.Apply(tt.r, tt.c, tt.in); tt.result != result {
+				t.Errorf("%+v IndexUnaryOp = %+v, want %+v", tt.name, result, tt.result)
+			}
+		})
+	}
+}